@@ -8,6 +8,7 @@ import (
 	"os"
 	"strings"
 
+	_ "github.com/gabriel3312cl/finances-game/backend/internal/bots/mcts" // Registers the MCTS strategy
 	handler "github.com/gabriel3312cl/finances-game/backend/internal/handler/http"
 	"github.com/gabriel3312cl/finances-game/backend/internal/handler/websocket"
 	"github.com/gabriel3312cl/finances-game/backend/internal/repository/postgres"
@@ -84,6 +85,17 @@ func main() {
 	mux.HandleFunc("/games/delete", handler.AuthMiddleware(userRepo, gameHandler.DeleteGame)) // Query param: ?id=...
 	mux.HandleFunc("/games/my", handler.AuthMiddleware(userRepo, gameHandler.GetMyGames))
 	mux.HandleFunc("/games/board", gameHandler.GetBoard) // public, or auth? Game board is generic. Public is fine.
+	mux.HandleFunc("/games/decks", handler.AuthMiddleware(userRepo, gameHandler.GetCardDecks))
+	mux.HandleFunc("/games/boards", handler.AuthMiddleware(userRepo, gameHandler.GetBoards))
+
+	// Admin Routes (card deck and board editors)
+	adminHandler := handler.NewAdminHandler(gameService)
+	mux.HandleFunc("/api/admin/decks", handler.AdminMiddleware(userRepo, adminHandler.Decks))
+	mux.HandleFunc("/api/admin/decks/", handler.AdminMiddleware(userRepo, adminHandler.Decks))
+	mux.HandleFunc("/api/admin/cards", handler.AdminMiddleware(userRepo, adminHandler.Cards))
+	mux.HandleFunc("/api/admin/cards/", handler.AdminMiddleware(userRepo, adminHandler.Cards))
+	mux.HandleFunc("/api/admin/boards", handler.AdminMiddleware(userRepo, adminHandler.Boards))
+	mux.HandleFunc("/api/admin/boards/", handler.AdminMiddleware(userRepo, adminHandler.Boards))
 
 	// Advisor Routes
 	advisorHandler := handler.NewAdvisorHandler(advisorService)
@@ -99,6 +111,36 @@ func main() {
 			advisorHandler.Chat(w, r)
 			return
 		}
+		// Route: /api/games/{id}/insurance/quote
+		if strings.HasSuffix(r.URL.Path, "insurance/quote") {
+			gameHandler.QuoteInsurance(w, r)
+			return
+		}
+		// Route: /api/games/{id}/trade/evaluate
+		if strings.HasSuffix(r.URL.Path, "trade/evaluate") {
+			gameHandler.EvaluateTrade(w, r)
+			return
+		}
+		// Route: /api/games/{id}/auctions
+		if strings.HasSuffix(r.URL.Path, "auctions") {
+			gameHandler.GetAuctions(w, r)
+			return
+		}
+		// Route: /api/games/{id}/treasury
+		if strings.HasSuffix(r.URL.Path, "treasury") {
+			gameHandler.GetTreasury(w, r)
+			return
+		}
+		// Route: /api/games/{id}/actions
+		if strings.HasSuffix(r.URL.Path, "/actions") {
+			gameHandler.GetLegalActions(w, r)
+			return
+		}
+		// Route: /api/games/{id}/ledger
+		if strings.HasSuffix(r.URL.Path, "ledger") {
+			gameHandler.GetLedger(w, r)
+			return
+		}
 		http.NotFound(w, r)
 	}))
 
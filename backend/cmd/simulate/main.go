@@ -0,0 +1,386 @@
+// Command simulate runs headless tournaments between bot personalities, with no database,
+// websocket hub or LLM, to tune strategies and spot rule imbalances:
+//
+//	go run ./cmd/simulate -bots classic,strategist,tycoon -games 2000
+//
+// Games are played on the classic board and deck of the seed data, bundled in
+// classic_board.json and classic_cards.json. -board and -cards play other ones instead: a board
+// definition exported from the admin API (GET /api/admin/boards/{id}) and a deck export
+// (GET /api/admin/decks/{id}/cards). LLM personalities play the heuristic strategy. It reports
+// win rates, game length, what made players go bankrupt, the rent each color group collected
+// and Elo ratings.
+package main
+
+import (
+	"cmp"
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/bots"
+	_ "github.com/gabriel3312cl/finances-game/backend/internal/bots/mcts" // Registers the MCTS strategy
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+	"github.com/gabriel3312cl/finances-game/backend/internal/service"
+)
+
+// The classic board and deck, as the seed data in database/02_schema_and_data.sql creates them.
+// Export them again from the admin API when the seed changes.
+var (
+	//go:embed classic_board.json
+	classicBoard []byte
+	//go:embed classic_cards.json
+	classicCards []byte
+)
+
+// Elo ratings start at 1500 and move by eloK per game, shared among the pairs of a table
+const (
+	eloStart = 1500.0
+	eloK     = 24.0
+)
+
+// result is what a tournament keeps of a game
+type result struct {
+	seats        []string       // Personality ID per seat
+	ranks        []int          // Final rank per seat, 1 = winner
+	rounds       int            // Rounds played
+	transactions int            // Ledger transactions followed
+	decided      bool           // A single player was left, not stopped at the round limit
+	bankruptcies []bankruptcy   // In the order they happened
+	groupRent    map[string]int // Color group -> rent paid on its properties
+}
+
+type bankruptcy struct {
+	personality string
+	cause       string
+}
+
+func main() {
+	boardPath := flag.String("board", "", "board definition JSON exported from the admin API (default: the classic board)")
+	cardsPath := flag.String("cards", "", "card deck JSON exported from the admin API (default: the classic deck)")
+	seats := flag.String("bots", "classic,apprentice", "comma-separated personality IDs, one per seat")
+	games := flag.Int("games", 1000, "games to play")
+	maxRounds := flag.Int("rounds", 200, "rounds after which a game is decided on net worth")
+	balance := flag.Int("balance", 1500, "starting cash")
+	settingsJSON := flag.String("settings", "", `house rules as GameSettings JSON, e.g. {"auction_format":"SEALED_SECOND"}`)
+	workers := flag.Int("workers", runtime.NumCPU(), "games played in parallel")
+	flag.Parse()
+
+	var board domain.BoardDefinition
+	if err := readJSON(*boardPath, classicBoard, &board); err != nil {
+		fail(err)
+	}
+	var deck []domain.Card
+	if err := readJSON(*cardsPath, classicCards, &deck); err != nil {
+		fail(err)
+	}
+	var settings domain.GameSettings
+	if *settingsJSON != "" {
+		if err := json.Unmarshal([]byte(*settingsJSON), &settings); err != nil {
+			fail(fmt.Errorf("settings: %w", err))
+		}
+	}
+	settings.BoardID, settings.CardDeckID = 0, 0
+
+	profiles, err := parseSeats(*seats)
+	if err != nil {
+		fail(err)
+	}
+	if _, err := service.NewSimulator(board, deck); err != nil {
+		fail(fmt.Errorf("%s: %w", cmp.Or(*boardPath, "classic board"), err))
+	}
+
+	jobs := make(chan int)
+	results := make(chan result)
+	var wg sync.WaitGroup
+	for w := 0; w < max(*workers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sim, _ := service.NewSimulator(board, deck)
+			for i, p := range profiles {
+				if strategy, ok := bots.Lookup(p.Strategy); ok && p.Strategy != bots.Heuristic && p.Strategy != bots.LLM {
+					sim.SetStrategy(fmt.Sprintf("BOT_%d", i+1), strategy)
+				}
+			}
+			for range jobs {
+				results <- play(sim, sim.NewGame(profiles, *balance, settings), *maxRounds)
+			}
+		}()
+	}
+	go func() {
+		for i := 0; i < *games; i++ {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	var all []result
+	for r := range results {
+		all = append(all, r)
+		if len(all)%100 == 0 {
+			fmt.Fprintf(os.Stderr, "\r%d/%d games", len(all), *games)
+		}
+	}
+	fmt.Fprintln(os.Stderr)
+	report(all, profiles, *maxRounds)
+}
+
+// play runs a game to its end, following the ledger to attribute bankruptcies and rent
+func play(sim *service.Simulator, game *domain.GameState, maxRounds int) result {
+	groups := make(map[string]string) // Tile name -> color group, or the tile type outside groups
+	for _, t := range game.Board {
+		groups[t.Name] = cmp.Or(t.GroupName, t.Type)
+	}
+	res := result{groupRent: make(map[string]int)}
+	lastPayment := make(map[string]string) // UserID -> cause of their latest payment
+	out := make(map[string]bool)
+	lastID := 0 // The ledger only keeps its latest entries, so it is followed by transaction ID
+
+	for game.Round < maxRounds && sim.Step(game) {
+		if game.Ledger != nil {
+			for _, tx := range game.Ledger.Transactions {
+				if tx.ID <= lastID {
+					continue
+				}
+				lastID = tx.ID
+				res.transactions++
+				cause := tx.Reason
+				if tx.Reason == domain.ReasonRent {
+					res.groupRent[groups[tx.Detail]] += tx.Amount
+					cause += " " + groups[tx.Detail]
+				}
+				if tx.Reason != domain.FlowBankruptcy && tx.Reason != domain.ReasonSavingsDeposit {
+					lastPayment[tx.From] = cause
+				}
+			}
+		}
+		for _, p := range game.Players {
+			if !p.IsActive && !out[p.UserID] {
+				out[p.UserID] = true
+				cause := lastPayment[p.UserID]
+				if cause == "" {
+					cause = "UNKNOWN"
+				}
+				res.bankruptcies = append(res.bankruptcies, bankruptcy{personality: p.BotPersonalityID, cause: strings.TrimSpace(cause)})
+			}
+		}
+	}
+	res.decided = game.Status == domain.GameStatusFinished
+	sim.Finish(game)
+
+	res.rounds = game.Round
+	rank := make(map[string]int)
+	for _, s := range game.Standings {
+		rank[s.UserID] = s.Rank
+	}
+	for _, p := range game.Players {
+		res.seats = append(res.seats, p.BotPersonalityID)
+		res.ranks = append(res.ranks, rank[p.UserID])
+	}
+	return res
+}
+
+func report(results []result, profiles []domain.BotProfile, maxRounds int) {
+	type stats struct {
+		name       string
+		strategy   string
+		seats      int
+		wins       int
+		bankrupted int
+	}
+	byID := make(map[string]*stats)
+	var order []string
+	for _, p := range profiles {
+		if _, ok := byID[p.ID]; !ok {
+			byID[p.ID] = &stats{name: p.Name, strategy: p.Strategy}
+			order = append(order, p.ID)
+		}
+	}
+
+	decided, rounds, transactions := 0, 0, 0
+	causes := make(map[string]int)
+	groupRent := make(map[string]int)
+	bankruptcies := 0
+	for _, r := range results {
+		rounds += r.rounds
+		transactions += r.transactions
+		if r.decided {
+			decided++
+		}
+		for i, id := range r.seats {
+			byID[id].seats++
+			if r.ranks[i] == 1 {
+				byID[id].wins++
+			}
+		}
+		for _, b := range r.bankruptcies {
+			byID[b.personality].bankrupted++
+			causes[b.cause]++
+			bankruptcies++
+		}
+		for g, amount := range r.groupRent {
+			groupRent[g] += amount
+		}
+	}
+	ratings := elo(results)
+	if len(results) == 0 {
+		return
+	}
+
+	fmt.Printf("Games: %d (%d won by the last player standing, %d decided on net worth after %d rounds)\n",
+		len(results), decided, len(results)-decided, maxRounds)
+	fmt.Printf("Average length: %.1f rounds, %.0f transactions\n\n", float64(rounds)/float64(len(results)),
+		float64(transactions)/float64(len(results)))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Personality\tStrategy\tSeats\tWins\tWin rate\tBankrupt\tElo\t")
+	sort.SliceStable(order, func(i, j int) bool { return ratings[order[i]] > ratings[order[j]] })
+	for _, id := range order {
+		s := byID[id]
+		strategy := s.strategy
+		if strategy == bots.LLM {
+			strategy = bots.Heuristic + "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%.1f%%\t%d\t%.0f\t\n", s.name, strategy, s.seats, s.wins,
+			percent(s.wins, s.seats), s.bankrupted, ratings[id])
+	}
+	w.Flush()
+	for _, id := range order {
+		if byID[id].strategy == bots.LLM {
+			fmt.Println("* LLM personalities play the heuristic strategy here")
+			break
+		}
+	}
+
+	fmt.Printf("\nBankruptcy causes (%d bankruptcies, latest payment before going under):\n", bankruptcies)
+	printShares(causes, bankruptcies)
+
+	total := 0
+	for _, amount := range groupRent {
+		total += amount
+	}
+	fmt.Printf("\nRent collected by color group ($%d in total):\n", total)
+	printShares(groupRent, total)
+}
+
+// elo rates each personality from the final ranks: every pair of seats at a table is a match
+// the better ranked one wins
+func elo(results []result) map[string]float64 {
+	ratings := make(map[string]float64)
+	rating := func(id string) float64 {
+		if r, ok := ratings[id]; ok {
+			return r
+		}
+		return eloStart
+	}
+	for _, r := range results {
+		n := len(r.seats)
+		if n < 2 {
+			continue
+		}
+		delta := make(map[string]float64)
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				a, b := r.seats[i], r.seats[j]
+				if a == b {
+					continue
+				}
+				expected := 1 / (1 + math.Pow(10, (rating(b)-rating(a))/400))
+				score := 0.5
+				if r.ranks[i] < r.ranks[j] {
+					score = 1
+				} else if r.ranks[i] > r.ranks[j] {
+					score = 0
+				}
+				change := eloK / float64(n-1) * (score - expected)
+				delta[a] += change
+				delta[b] -= change
+			}
+		}
+		for _, id := range r.seats {
+			ratings[id] = rating(id) + delta[id]
+			delta[id] = 0 // A personality in several seats moves once
+		}
+	}
+	return ratings
+}
+
+func printShares(counts map[string]int, total int) {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, k := range keys {
+		name := k
+		if name == "" {
+			name = "(none)"
+		}
+		fmt.Fprintf(w, "  %s\t%d\t%.1f%%\t\n", name, counts[k], percent(counts[k], total))
+	}
+	w.Flush()
+}
+
+func parseSeats(list string) ([]domain.BotProfile, error) {
+	var profiles []domain.BotProfile
+	for _, id := range strings.Split(list, ",") {
+		id = strings.TrimSpace(id)
+		found := false
+		for _, p := range domain.BotPersonalities {
+			if p.ID == id {
+				profiles = append(profiles, p)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown personality %q", id)
+		}
+	}
+	if len(profiles) < 2 {
+		return nil, fmt.Errorf("a game needs at least 2 bots, got %d", len(profiles))
+	}
+	return profiles, nil
+}
+
+// readJSON decodes a file, or the bundled default when no path is given
+func readJSON(path string, fallback []byte, v any) error {
+	data := fallback
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: invalid JSON: %w", cmp.Or(path, "bundled default"), err)
+	}
+	return nil
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(n) / float64(total)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
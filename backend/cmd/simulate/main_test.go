@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+	"github.com/gabriel3312cl/finances-game/backend/internal/service"
+)
+
+func TestPlay_FollowsTheLedgerPastItsCap(t *testing.T) {
+	var board domain.BoardDefinition
+	var deck []domain.Card
+	if err := readJSON("", classicBoard, &board); err != nil {
+		t.Fatal(err)
+	}
+	if err := readJSON("", classicCards, &deck); err != nil {
+		t.Fatal(err)
+	}
+	sim, err := service.NewSimulator(board, deck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profiles, err := parseSeats("classic,tycoon,saver")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deep pockets keep everyone in the game for every round
+	game := sim.NewGame(profiles, 20000, domain.GameSettings{})
+	res := play(sim, game, 150)
+	if game.Ledger.NextID <= domain.MaxLedgerEntries {
+		t.Fatalf("only %d transactions; the game didn't outgrow the ledger", game.Ledger.NextID)
+	}
+	// Every transaction is looked at once, the ones trimmed from the ledger included
+	if res.transactions != game.Ledger.NextID {
+		t.Errorf("followed %d of %d transactions", res.transactions, game.Ledger.NextID)
+	}
+
+	labels := make(map[string]bool) // Color groups and tile types
+	for _, tile := range game.Board {
+		labels[tile.GroupName], labels[tile.Type] = true, true
+	}
+	rent := 0
+	for group, amount := range res.groupRent {
+		if group == "" || !labels[group] {
+			t.Errorf("$%d of rent under %q, which is neither a color group nor a tile type", amount, group)
+		}
+		rent += amount
+	}
+	if rent == 0 {
+		t.Error("no rent was attributed")
+	}
+}
@@ -0,0 +1,56 @@
+// Command validate-board checks board definitions exported from the admin API (or written by
+// hand) before they are imported:
+//
+//	go run ./cmd/validate-board barrio.json [other.json ...]
+//
+// Every problem is printed; the exit status is 1 if any board has problems.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/boards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: validate-board <board.json> [...]")
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, path := range os.Args[1:] {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			failed = true
+			continue
+		}
+		var def domain.BoardDefinition
+		if err := json.Unmarshal(data, &def); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: invalid JSON: %v\n", path, err)
+			failed = true
+			continue
+		}
+		if def.Size == 0 {
+			def.Size = len(def.Tiles)
+		}
+
+		report := boards.Validate(&def)
+		if report.Valid {
+			fmt.Printf("%s: OK (%d tiles)\n", path, len(def.Tiles))
+			continue
+		}
+		failed = true
+		fmt.Printf("%s: %d problems\n", path, len(report.Problems))
+		for _, p := range report.Problems {
+			fmt.Printf("  %s\n", p)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
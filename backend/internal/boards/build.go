@@ -0,0 +1,86 @@
+// Package boards lays out and validates board definitions. A definition is validated as a whole
+// so that an editor gets every problem at once, and no game starts on a board with problems.
+package boards
+
+import "github.com/gabriel3312cl/finances-game/backend/internal/domain"
+
+// Corner names used when a board definition doesn't name them
+var cornerNames = map[string]string{
+	domain.TileGo:          "SALIDA",
+	domain.TileJail:        "CÁRCEL",
+	domain.TileFreeParking: "PARADA LIBRE",
+	domain.TileGoToJail:    "VAYA A LA CÁRCEL",
+}
+
+// Build lays out the tiles of a game from a board definition
+func Build(def *domain.BoardDefinition) []domain.Tile {
+	groups := make(map[string]domain.PropertyGroup)
+	for _, g := range def.Groups {
+		groups[g.ID] = g
+	}
+	corners := cornerTypes(def.Corners)
+
+	tiles := make([]domain.Tile, len(def.Tiles))
+	for i, bt := range def.Tiles {
+		tile := domain.Tile{ID: i, Type: bt.Type, Name: bt.Name, PropertyID: bt.Type}
+
+		if prop := bt.Property; prop != nil {
+			tile.PropertyID = PropertyID(prop)
+			tile.Slug = prop.Slug
+			tile.Price = prop.Price
+			tile.Rent = prop.RentBase     // Base rent current
+			tile.RentRule = prop.RentRule // Pass to frontend
+
+			// Full info
+			tile.RentBase = prop.RentBase
+			tile.RentColorGroup = prop.RentColorGroup
+			tile.Rent1House = prop.Rent1House
+			tile.Rent2House = prop.Rent2House
+			tile.Rent3House = prop.Rent3House
+			tile.Rent4House = prop.Rent4House
+			tile.RentHotel = prop.RentHotel
+			tile.HouseCost = prop.HouseCost
+			tile.HotelCost = prop.HotelCost
+			tile.MortgageValue = prop.MortgageValue
+			tile.UnmortgageValue = prop.UnmortgageValue
+
+			tile.GroupIdentifier = prop.GroupID
+			tile.GroupName = prop.GroupName
+			tile.GroupColor = prop.GroupColor
+			if g, ok := groups[prop.GroupID]; ok {
+				if tile.GroupName == "" {
+					tile.GroupName = g.Name
+				}
+				if tile.GroupColor == "" {
+					tile.GroupColor = g.Color
+				}
+			}
+		} else if corner, ok := corners[i]; ok {
+			// Corners keep their rule wherever the definition puts them
+			tile.Type, tile.PropertyID = corner, corner
+			if tile.Name == "" || tile.Name == "CORNER" {
+				tile.Name = cornerNames[corner]
+			}
+		}
+		tiles[i] = tile
+	}
+	return tiles
+}
+
+// PropertyID is the key of a property in PropertyOwnership: its UUID on the classic board,
+// its slug on custom boards that don't set one
+func PropertyID(prop *domain.Property) string {
+	if prop.ID != "" {
+		return prop.ID
+	}
+	return prop.Slug
+}
+
+func cornerTypes(c domain.BoardCorners) map[int]string {
+	return map[int]string{
+		c.Go:          domain.TileGo,
+		c.Jail:        domain.TileJail,
+		c.FreeParking: domain.TileFreeParking,
+		c.GoToJail:    domain.TileGoToJail,
+	}
+}
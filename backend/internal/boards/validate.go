@@ -0,0 +1,271 @@
+package boards
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// MinSize is the smallest board a game can be played on
+const MinSize = 8
+
+// Avenues a color group may have, as on the classic board
+const (
+	MinGroupSize = 2
+	MaxGroupSize = 3
+)
+
+// Purchasable tile types, which must carry a property
+var purchasable = map[string]bool{
+	"PROPERTY":        true,
+	"RAILROAD":        true,
+	"UTILITY":         true,
+	"PARK":            true,
+	"ATTRACTION":      true,
+	"DICE_MULTIPLIER": true,
+}
+
+// Special tile types without a property
+var special = map[string]bool{
+	"CHANCE":               true,
+	"COMMUNITY":            true,
+	"CORNER":               true,
+	domain.TileIncomeTax:   true,
+	domain.TileLuxuryTax:   true,
+	domain.TileGo:          true,
+	domain.TileJail:        true,
+	domain.TileFreeParking: true,
+	domain.TileGoToJail:    true,
+}
+
+// Problem is one reason a board can't be played. Position is the tile index, -1 for problems
+// of the whole board.
+type Problem struct {
+	Position int    `json:"position"`
+	Message  string `json:"message"`
+}
+
+func (p Problem) String() string {
+	if p.Position < 0 {
+		return p.Message
+	}
+	return fmt.Sprintf("casilla %d: %s", p.Position, p.Message)
+}
+
+// Report is the outcome of validating a board
+type Report struct {
+	Valid    bool      `json:"valid"`
+	Problems []Problem `json:"problems,omitempty"`
+}
+
+// Validate checks a board definition for completeness and returns every problem found: size and
+// corners, every position filled with a known tile, property data (rent tables that never go
+// down, mortgage within the price), groups of 2 or 3 buildable properties sharing their build
+// costs, and exactly one GO, JAIL and GO_TO_JAIL once laid out.
+func Validate(def *domain.BoardDefinition) Report {
+	v := &validator{}
+	v.checkSize(def)
+	v.checkCorners(def)
+	v.checkTiles(def)
+	v.checkGroups(def)
+	v.checkLayout(def)
+
+	sort.SliceStable(v.problems, func(i, j int) bool { return v.problems[i].Position < v.problems[j].Position })
+	return Report{Valid: len(v.problems) == 0, Problems: v.problems}
+}
+
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) add(pos int, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Position: pos, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) checkSize(def *domain.BoardDefinition) {
+	if def.Size != len(def.Tiles) {
+		v.add(-1, "el tamaño (%d) no coincide con el número de casillas (%d)", def.Size, len(def.Tiles))
+	}
+	if len(def.Tiles) < MinSize {
+		v.add(-1, "el tablero necesita al menos %d casillas", MinSize)
+	}
+}
+
+func (v *validator) checkCorners(def *domain.BoardDefinition) {
+	c := def.Corners
+	corners := []struct {
+		name string
+		pos  int
+	}{{"SALIDA", c.Go}, {"CÁRCEL", c.Jail}, {"PARADA LIBRE", c.FreeParking}, {"VAYA A LA CÁRCEL", c.GoToJail}}
+
+	seen := make(map[int]string)
+	for _, corner := range corners {
+		if corner.pos < 0 || corner.pos >= len(def.Tiles) {
+			v.add(-1, "la esquina %s (%d) está fuera del tablero", corner.name, corner.pos)
+			continue
+		}
+		if other, dup := seen[corner.pos]; dup {
+			v.add(corner.pos, "las esquinas %s y %s ocupan la misma casilla", other, corner.name)
+			continue
+		}
+		seen[corner.pos] = corner.name
+		if def.Tiles[corner.pos].Property != nil {
+			v.add(corner.pos, "la esquina %s no puede ser una propiedad", corner.name)
+		}
+	}
+}
+
+func (v *validator) checkTiles(def *domain.BoardDefinition) {
+	ids := make(map[string]int)
+	slugs := make(map[string]int)
+	for i, t := range def.Tiles {
+		switch {
+		case t.Type == "" || t.Type == "TILE":
+			v.add(i, "casilla vacía")
+			continue
+		case purchasable[t.Type] && t.Property == nil:
+			v.add(i, "la casilla %s no tiene los datos de su propiedad", t.Type)
+			continue
+		case !purchasable[t.Type] && !special[t.Type]:
+			v.add(i, "tipo de casilla desconocido: %s", t.Type)
+			continue
+		}
+		if t.Property == nil {
+			continue
+		}
+
+		p := t.Property
+		if !purchasable[t.Type] {
+			v.add(i, "una casilla %s no puede ser una propiedad", t.Type)
+		}
+		id := PropertyID(p)
+		if id == "" {
+			v.add(i, "la propiedad necesita un slug")
+		} else if prev, dup := ids[id]; dup {
+			v.add(i, "la propiedad %s ya está en la casilla %d", id, prev)
+		} else {
+			ids[id] = i
+		}
+		if p.ID != "" && p.Slug != "" {
+			// Cards move to properties by slug
+			if prev, dup := slugs[p.Slug]; dup {
+				v.add(i, "el slug %s ya se usa en la casilla %d", p.Slug, prev)
+			}
+			slugs[p.Slug] = i
+		}
+		if strings.TrimSpace(p.Name) == "" {
+			v.add(i, "la propiedad no tiene nombre")
+		}
+		if p.Price <= 0 {
+			v.add(i, "%s: el precio debe ser positivo", p.Name)
+		}
+		if p.MortgageValue < 0 || p.MortgageValue > p.Price {
+			v.add(i, "%s: la hipoteca ($%d) no puede superar el precio ($%d)", p.Name, p.MortgageValue, p.Price)
+		}
+		if p.UnmortgageValue < p.MortgageValue {
+			v.add(i, "%s: levantar la hipoteca ($%d) no puede costar menos que la hipoteca ($%d)", p.Name, p.UnmortgageValue, p.MortgageValue)
+		}
+		if t.Type == "PROPERTY" {
+			v.checkRentTable(i, p)
+		}
+	}
+}
+
+// checkRentTable requires a buildable property to have every rent, each at least the previous one
+func (v *validator) checkRentTable(pos int, p *domain.Property) {
+	steps := []struct {
+		name string
+		rent int
+	}{
+		{"base", p.RentBase}, {"grupo completo", p.RentColorGroup},
+		{"1 casa", p.Rent1House}, {"2 casas", p.Rent2House}, {"3 casas", p.Rent3House}, {"4 casas", p.Rent4House},
+		{"hotel", p.RentHotel},
+	}
+	for k, s := range steps {
+		if s.rent <= 0 {
+			// The full group rent is optional: it defaults to double the base rent
+			if k == 1 && s.rent == 0 {
+				continue
+			}
+			v.add(pos, "%s: falta la renta con %s", p.Name, s.name)
+			return
+		}
+	}
+	prev := steps[0]
+	for _, s := range steps[1:] {
+		if s.rent == 0 {
+			continue
+		}
+		if s.rent < prev.rent {
+			v.add(pos, "%s: la renta con %s ($%d) es menor que con %s ($%d)", p.Name, s.name, s.rent, prev.name, prev.rent)
+		}
+		prev = s
+	}
+	if p.HouseCost <= 0 || p.HotelCost <= 0 {
+		v.add(pos, "%s: falta el costo de casas u hoteles", p.Name)
+	}
+}
+
+// checkGroups requires buildable properties to belong to a declared group of at least two, all
+// with the same build costs
+func (v *validator) checkGroups(def *domain.BoardDefinition) {
+	declared := make(map[string]bool)
+	for _, g := range def.Groups {
+		if g.ID == "" {
+			v.add(-1, "hay un grupo sin ID")
+		} else if declared[g.ID] {
+			v.add(-1, "el grupo %s está declarado dos veces", g.ID)
+		}
+		declared[g.ID] = true
+	}
+
+	members := make(map[string][]int)
+	var order []string
+	for i, t := range def.Tiles {
+		if t.Property == nil || t.Type != "PROPERTY" {
+			continue
+		}
+		gid := t.Property.GroupID
+		if gid == "" {
+			v.add(i, "%s: una avenida debe pertenecer a un grupo", t.Property.Name)
+			continue
+		}
+		if len(def.Groups) > 0 && !declared[gid] {
+			v.add(i, "%s: el grupo %s no está declarado", t.Property.Name, gid)
+		}
+		if _, ok := members[gid]; !ok {
+			order = append(order, gid)
+		}
+		members[gid] = append(members[gid], i)
+	}
+
+	for _, gid := range order {
+		idx := members[gid]
+		if len(idx) < MinGroupSize || len(idx) > MaxGroupSize {
+			v.add(idx[0], "el grupo %s tiene %d avenidas y debe tener entre %d y %d", gid, len(idx), MinGroupSize, MaxGroupSize)
+		}
+		first := def.Tiles[idx[0]].Property
+		for _, i := range idx[1:] {
+			p := def.Tiles[i].Property
+			if p.HouseCost != first.HouseCost || p.HotelCost != first.HotelCost {
+				v.add(i, "%s: el grupo %s construye a $%d/$%d y esta avenida a $%d/$%d",
+					p.Name, gid, first.HouseCost, first.HotelCost, p.HouseCost, p.HotelCost)
+			}
+		}
+	}
+}
+
+// checkLayout counts the rule tiles once corners are applied
+func (v *validator) checkLayout(def *domain.BoardDefinition) {
+	counts := make(map[string]int)
+	for _, t := range Build(def) {
+		counts[t.Type]++
+	}
+	for _, tileType := range []string{domain.TileGo, domain.TileJail, domain.TileGoToJail} {
+		if counts[tileType] != 1 {
+			v.add(-1, "el tablero debe tener exactamente una casilla %s (tiene %d)", tileType, counts[tileType])
+		}
+	}
+}
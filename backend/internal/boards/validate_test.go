@@ -0,0 +1,82 @@
+package boards
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func avenue(slug string, group string, houseCost int) *domain.Property {
+	return &domain.Property{
+		Slug: slug, Name: slug, Type: "PROPERTY", GroupID: group, Price: 100,
+		RentBase: 6, Rent1House: 30, Rent2House: 90, Rent3House: 270, Rent4House: 400, RentHotel: 550,
+		HouseCost: houseCost, HotelCost: houseCost, MortgageValue: 50, UnmortgageValue: 55,
+	}
+}
+
+func testBoard() *domain.BoardDefinition {
+	def := &domain.BoardDefinition{
+		Name:    "Barrio",
+		Size:    8,
+		Corners: domain.BoardCorners{Go: 0, Jail: 2, FreeParking: 4, GoToJail: 6},
+		Groups:  []domain.PropertyGroup{{ID: "g1", Name: "Centro"}},
+		Tiles:   make([]domain.BoardTile, 8),
+	}
+	for i := range def.Tiles {
+		def.Tiles[i] = domain.BoardTile{Type: "CORNER"}
+	}
+	def.Tiles[1] = domain.BoardTile{Type: "PROPERTY", Property: avenue("a", "g1", 50)}
+	def.Tiles[3] = domain.BoardTile{Type: "PROPERTY", Property: avenue("b", "g1", 50)}
+	def.Tiles[5] = domain.BoardTile{Type: "CHANCE"}
+	def.Tiles[7] = domain.BoardTile{Type: domain.TileLuxuryTax}
+	return def
+}
+
+func TestValidate_AcceptsCompleteBoard(t *testing.T) {
+	if report := Validate(testBoard()); !report.Valid {
+		t.Fatalf("complete board rejected: %v", report.Problems)
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	def := testBoard()
+	def.Tiles[5] = domain.BoardTile{Type: "TILE", Name: "Unknown"} // Seed typo
+	def.Tiles[1].Property.Rent3House = 10                          // Rent goes down
+	def.Tiles[1].Property.MortgageValue = 500                      // Above the price
+	def.Tiles[3].Property.HouseCost = 80                           // Group builds at 50
+	def.Tiles[7] = domain.BoardTile{Type: domain.TileGo}           // Second GO
+
+	report := Validate(def)
+	want := []string{"casilla vacía", "es menor que", "no puede superar el precio", "construye a", "exactamente una casilla GO"}
+	if report.Valid || len(report.Problems) < len(want) {
+		t.Fatalf("problems: %v", report.Problems)
+	}
+	for _, w := range want {
+		found := false
+		for _, p := range report.Problems {
+			found = found || strings.Contains(p.Message, w)
+		}
+		if !found {
+			t.Errorf("missing problem %q in %v", w, report.Problems)
+		}
+	}
+}
+
+func TestValidate_GroupSizes(t *testing.T) {
+	for _, tc := range []struct {
+		avenues int
+		valid   bool
+	}{{1, false}, {2, true}, {3, true}, {4, false}} {
+		def := testBoard()
+		for i, pos := range []int{1, 3, 5, 7} {
+			def.Tiles[pos] = domain.BoardTile{Type: "CHANCE"}
+			if i < tc.avenues {
+				def.Tiles[pos] = domain.BoardTile{Type: "PROPERTY", Property: avenue(string(rune('a'+i)), "g1", 50)}
+			}
+		}
+		if report := Validate(def); report.Valid != tc.valid {
+			t.Errorf("group of %d avenues: valid %v, problems %v", tc.avenues, report.Valid, report.Problems)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package mcts
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/bots"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+	"github.com/gabriel3312cl/finances-game/backend/internal/valuation"
+)
+
+// maxCandidates bounds the options of a decision, so the budget isn't spread too thin
+const maxCandidates = 8
+
+// maxTradeCandidates bounds the trade proposals compared in a turn
+const maxTradeCandidates = 2
+
+// wait lets a Dutch clock run down instead of taking its price. It is never applied.
+var wait = &domain.BotAction{Reason: "Espero a que baje el precio"}
+
+// Bids of a sealed envelope, as fractions of the list price
+var sealedBidSteps = []float64{0.5, 0.75, 1, 1.25, 1.5}
+
+// legalSet is what the rules let a player do right now, by action
+type legalSet map[string]domain.LegalAction
+
+func legalFor(rules bots.Rules, game *domain.GameState, userID string) legalSet {
+	set := make(legalSet)
+	for _, a := range rules.LegalActions(game, userID) {
+		set[a.Action] = a
+	}
+	return set
+}
+
+func (l legalSet) has(action string) bool {
+	_, ok := l[action]
+	return ok
+}
+
+// candidates lists the actions worth comparing at this point, the default action first. Every
+// option comes from the legal actions, so the search follows the rules' own checks. A single
+// candidate means there's no real choice to search.
+func candidates(rules bots.Rules, game *domain.GameState, bot *domain.PlayerState, fallback *domain.BotAction) []*domain.BotAction {
+	legal := legalFor(rules, game, bot.UserID)
+	var options []*domain.BotAction
+	switch {
+	case game.ActiveAuction != nil && game.ActiveAuction.IsActive:
+		options = auctionOptions(game, legal)
+	case bot.Balance < 0:
+		options = liquidationOptions(game, bot, legal)
+	case game.Status == domain.GameStatusActive && game.CurrentTurnID == bot.UserID && game.Dice[0] != 0:
+		options = turnOptions(rules, game, bot, legal)
+	}
+
+	// A Dutch clock charges its price whatever the amount bid
+	dutch := game.ActiveAuction != nil && game.ActiveAuction.Format == domain.AuctionDutch
+	var out []*domain.BotAction
+	seen := make(map[string]bool)
+	for _, o := range append([]*domain.BotAction{fallback}, options...) {
+		if o == nil || len(out) == maxCandidates {
+			continue
+		}
+		amount := o.Amount
+		if dutch {
+			amount = 0
+		}
+		key := o.Action + "|" + o.PropertyID + "|" + string(o.Payload) + "|" + fmt.Sprint(amount)
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// auctionOptions compares passing with bidding: the minimum raise of an open auction,
+// envelopes around the list price, or taking a Dutch clock's price against waiting for a lower one
+func auctionOptions(game *domain.GameState, legal legalSet) []*domain.BotAction {
+	if !legal.has("PASS_AUCTION") {
+		return nil // Leading, passed or already in an envelope
+	}
+	auction := game.ActiveAuction
+	options := []*domain.BotAction{{Action: "PASS_AUCTION", Reason: "Me retiro de la subasta"}}
+	bid, canBid := legal["BID"]
+
+	switch auction.Format {
+	case domain.AuctionDutch:
+		options = []*domain.BotAction{wait}
+		if canBid {
+			options = append(options, &domain.BotAction{Action: "BID", Amount: bid.MinAmount, Reason: fmt.Sprintf("Acepto el precio de $%d", bid.MinAmount)})
+		}
+	case domain.AuctionSealedFirst, domain.AuctionSealedSecond:
+		price := auction.OpeningBid
+		for _, t := range game.Board {
+			if t.PropertyID == auction.PropertyID {
+				price = t.Price
+			}
+		}
+		for _, f := range sealedBidSteps {
+			if amount := int(float64(price) * f); canBid && amount >= bid.MinAmount && amount <= bid.MaxAmount {
+				options = append(options, &domain.BotAction{Action: "BID", Amount: amount, Reason: fmt.Sprintf("Oferta en sobre de $%d", amount)})
+			}
+		}
+	default:
+		if canBid {
+			options = append(options, &domain.BotAction{Action: "BID", Amount: bid.MinAmount, Reason: fmt.Sprintf("Subo a $%d", bid.MinAmount)})
+		}
+	}
+	return options
+}
+
+// liquidationOptions lists the ways to raise cash when in debt
+func liquidationOptions(game *domain.GameState, bot *domain.PlayerState, legal legalSet) []*domain.BotAction {
+	var options []*domain.BotAction
+	if legal.has("WITHDRAW_SAVINGS") {
+		options = append(options, &domain.BotAction{Action: "WITHDRAW_SAVINGS",
+			Payload: json.RawMessage(fmt.Sprintf(`{"amount": %d}`, min(bot.Savings, -bot.Balance))), Reason: "Retiro ahorros para pagar"})
+	}
+	for _, id := range legal["SELL_BUILDING"].PropertyIDs {
+		options = append(options, &domain.BotAction{Action: "SELL_BUILDING", PropertyID: id, Reason: "Vendo construcciones en " + tileName(game, id)})
+	}
+	for _, id := range legal["MORTGAGE_PROPERTY"].PropertyIDs {
+		options = append(options, &domain.BotAction{Action: "MORTGAGE_PROPERTY", PropertyID: id, Reason: "Hipoteco " + tileName(game, id)})
+	}
+	return options
+}
+
+// turnOptions lists the choices once the dice are rolled: buying or auctioning the tile landed
+// on, then ending the turn, proposing trades, mortgaging for cash, building or lifting mortgages
+func turnOptions(rules bots.Rules, game *domain.GameState, bot *domain.PlayerState, legal legalSet) []*domain.BotAction {
+	if auction, ok := legal["START_AUCTION"]; ok {
+		name := tileName(game, auction.PropertyIDs[0])
+		options := []*domain.BotAction{{Action: "START_AUCTION", Reason: "Subasto " + name}}
+		if legal.has("BUY_PROPERTY") {
+			options = append(options, &domain.BotAction{Action: "BUY_PROPERTY", Reason: "Compro " + name})
+		}
+		return options
+	}
+	if !legal.has("END_TURN") {
+		return nil
+	}
+
+	options := []*domain.BotAction{{Action: "END_TURN", Reason: "Guardo el efectivo"}}
+	if legal.has("INITIATE_TRADE") {
+		options = append(options, tradeOptions(rules, game, bot, legal["INITIATE_TRADE"].TargetIDs)...)
+	}
+	if m := mortgageOption(game, bot, legal["MORTGAGE_PROPERTY"].PropertyIDs); m != nil {
+		options = append(options, m)
+	}
+	for _, id := range legal["BUY_BUILDING"].PropertyIDs {
+		options = append(options, &domain.BotAction{Action: "BUY_BUILDING", PropertyID: id, Reason: "Construyo en " + tileName(game, id)})
+	}
+	for _, id := range legal["UNMORTGAGE_PROPERTY"].PropertyIDs {
+		options = append(options, &domain.BotAction{Action: "UNMORTGAGE_PROPERTY", PropertyID: id, Reason: "Levanto la hipoteca de " + tileName(game, id)})
+	}
+	return options
+}
+
+// tradeOptions proposes buying a rival's property for cash, sweetened until the owner doesn't
+// lose by it, as long as the bot still gains. Only properties the owner could mortgage are
+// asked for: those are the ones the rules let change hands. The best proposals for the bot
+// come first.
+func tradeOptions(rules bots.Rules, game *domain.GameState, bot *domain.PlayerState, targets []string) []*domain.BotAction {
+	type proposal struct {
+		action *domain.BotAction
+		net    int
+	}
+	var proposals []proposal
+	for _, owner := range targets {
+		for _, id := range legalFor(rules, game, owner)["MORTGAGE_PROPERTY"].PropertyIDs {
+			i := slices.IndexFunc(game.Board, func(t domain.Tile) bool { return t.PropertyID == id })
+			if i < 0 || game.Board[i].Price <= 0 {
+				continue
+			}
+			t := game.Board[i]
+			cash := t.Price
+			score := valuation.ScoreTrade(game, []domain.TradeLeg{
+				{FromID: bot.UserID, ToID: owner, Cash: cash},
+				{FromID: owner, ToID: bot.UserID, Properties: []string{id}},
+			}, nil)
+			mine, theirs := score.Side(bot.UserID), score.Side(owner)
+			if mine == nil || theirs == nil {
+				continue
+			}
+			extra := max(-theirs.Net, 0)
+			if cash += extra; cash > bot.Balance || mine.Net-extra <= 0 {
+				continue
+			}
+			proposals = append(proposals, proposal{net: mine.Net - extra, action: &domain.BotAction{
+				Action:  "INITIATE_TRADE",
+				Payload: json.RawMessage(fmt.Sprintf(`{"target_id":"%s","offer_cash":%d,"request_properties":["%s"]}`, owner, cash, id)),
+				Reason:  fmt.Sprintf("Ofrezco $%d por %s", cash, t.Name),
+			}})
+		}
+	}
+	sort.SliceStable(proposals, func(i, j int) bool { return proposals[i].net > proposals[j].net })
+
+	var options []*domain.BotAction
+	for _, p := range proposals[:min(len(proposals), maxTradeCandidates)] {
+		options = append(options, p.action)
+	}
+	return options
+}
+
+// mortgageOption raises cash without being in debt, on the mortgageable property worth least
+// to the bot
+func mortgageOption(game *domain.GameState, bot *domain.PlayerState, ids []string) *domain.BotAction {
+	var option *domain.BotAction
+	least := 0
+	for _, id := range ids {
+		v := valuation.Property(game, bot.UserID, id)
+		if v == nil {
+			continue
+		}
+		if option == nil || v.Value < least {
+			least = v.Value
+			option = &domain.BotAction{Action: "MORTGAGE_PROPERTY", PropertyID: id, Reason: "Hipoteco " + tileName(game, id) + " para tener efectivo"}
+		}
+	}
+	return option
+}
+
+func tileName(game *domain.GameState, propertyID string) string {
+	for _, t := range game.Board {
+		if t.PropertyID == propertyID {
+			return t.Name
+		}
+	}
+	return propertyID
+}
@@ -0,0 +1,134 @@
+// Package mcts is a bot strategy that searches with Monte Carlo rollouts. At every real choice
+// (buying or auctioning, bidding, building, mortgaging, trading) it plays the game on from a
+// copy of the current state many times, everyone following the default strategy, and takes the
+// action with the best outcomes. Rollouts are spread over the candidates with UCB1, so the
+// promising ones get most of the budget.
+package mcts
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/bots"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// Name is the strategy name profiles use
+const Name = "MCTS"
+
+func init() {
+	bots.Register(Name, Strategy{})
+}
+
+// Budget bounds the search of one decision: it stops at whichever limit comes first
+type Budget struct {
+	Iterations int           // Rollouts
+	Time       time.Duration // Wall clock
+	Horizon    int           // Rounds played per rollout before scoring
+}
+
+// DefaultBudget is used for the limits a profile doesn't set
+var DefaultBudget = Budget{Iterations: 200, Time: time.Second, Horizon: 5}
+
+// exploration weighs trying rarely played candidates against replaying the best ones. Scores
+// are shares of the wealth in play, so differences are small and the weight is too.
+const exploration = 0.3
+
+// BudgetFor returns the search budget of a bot personality
+func BudgetFor(profile domain.BotProfile) Budget {
+	budget := DefaultBudget
+	if profile.SearchIterations > 0 {
+		budget.Iterations = profile.SearchIterations
+	}
+	if profile.SearchTimeMs > 0 {
+		budget.Time = time.Duration(profile.SearchTimeMs) * time.Millisecond
+	}
+	return budget
+}
+
+// Strategy searches with the budget of the bot's profile
+type Strategy struct{}
+
+// Decide plays the default action when there's nothing to choose, or the game can't be simulated
+func (Strategy) Decide(view bots.View) *domain.BotAction {
+	if view.Rules == nil {
+		return nil
+	}
+	fallback := view.Rules.Default(view.Game, view.Bot.UserID)
+	options := candidates(view.Rules, view.Game, view.Bot, fallback)
+	if len(options) < 2 {
+		return fallback
+	}
+	if best := Search(view, options, BudgetFor(view.Profile)); best.Action != "" {
+		return best
+	}
+	return nil // Waiting
+}
+
+// arm is a candidate action and the outcomes of its rollouts
+type arm struct {
+	action *domain.BotAction
+	visits int
+	total  float64
+}
+
+func (a *arm) mean() float64 {
+	if a.visits == 0 {
+		return 0
+	}
+	return a.total / float64(a.visits)
+}
+
+// Search spreads the rollouts of a budget over the options and returns the most played one,
+// the first option on ties
+func Search(view bots.View, options []*domain.BotAction, budget Budget) *domain.BotAction {
+	root := view.Rules.Clone(view.Game)
+	if root == nil {
+		return options[0]
+	}
+	arms := make([]*arm, len(options))
+	for i, o := range options {
+		arms[i] = &arm{action: o}
+	}
+
+	deadline := time.Now().Add(budget.Time)
+	n := 0
+	for ; n < budget.Iterations && time.Now().Before(deadline); n++ {
+		a := selectArm(arms, n)
+		game := view.Rules.Clone(root)
+		if a.action != wait {
+			view.Rules.Apply(game, view.Bot.UserID, a.action)
+		}
+		view.Rules.Playout(game, budget.Horizon)
+		a.visits++
+		a.total += view.Rules.Score(game, view.Bot.UserID)
+	}
+
+	best := arms[0]
+	for _, a := range arms[1:] {
+		if a.visits > best.visits || (a.visits == best.visits && a.mean() > best.mean()) {
+			best = a
+		}
+	}
+	action := *best.action
+	action.Reason = fmt.Sprintf("%s (%d de %d simulaciones, %.0f%% del patrimonio en juego)",
+		action.Reason, best.visits, n, best.mean()*100)
+	return &action
+}
+
+// selectArm plays every candidate once, then the one with the best upper confidence bound
+func selectArm(arms []*arm, played int) *arm {
+	var best *arm
+	bestBound := math.Inf(-1)
+	for _, a := range arms {
+		if a.visits == 0 {
+			return a
+		}
+		bound := a.mean() + exploration*math.Sqrt(math.Log(float64(played))/float64(a.visits))
+		if bound > bestBound {
+			best, bestBound = a, bound
+		}
+	}
+	return best
+}
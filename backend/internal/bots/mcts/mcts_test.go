@@ -0,0 +1,141 @@
+package mcts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/bots"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// coinRules scores a rollout by the action applied first: its odds of winning. The legal
+// actions of each player are given.
+type coinRules struct {
+	odds  map[string]float64
+	legal map[string][]domain.LegalAction
+}
+
+func (r coinRules) Clone(game *domain.GameState) *domain.GameState {
+	clone := *game
+	return &clone
+}
+
+func (r coinRules) Apply(game *domain.GameState, userID string, action *domain.BotAction) {
+	game.LastAction = action.Action
+}
+
+func (r coinRules) LegalActions(game *domain.GameState, userID string) []domain.LegalAction {
+	return r.legal[userID]
+}
+
+func (r coinRules) Default(game *domain.GameState, userID string) *domain.BotAction {
+	return &domain.BotAction{Action: "END_TURN"}
+}
+
+func (r coinRules) Playout(game *domain.GameState, rounds int) {}
+
+func (r coinRules) Score(game *domain.GameState, userID string) float64 {
+	return r.odds[game.LastAction]
+}
+
+func TestSearch_PrefersTheBetterAction(t *testing.T) {
+	view := bots.View{
+		Game:  &domain.GameState{},
+		Bot:   &domain.PlayerState{UserID: "BOT_1"},
+		Rules: coinRules{odds: map[string]float64{"END_TURN": 0.3, "BUY_PROPERTY": 0.7, "START_AUCTION": 0.4}},
+	}
+	options := []*domain.BotAction{{Action: "END_TURN"}, {Action: "BUY_PROPERTY"}, {Action: "START_AUCTION"}}
+
+	got := Search(view, options, Budget{Iterations: 400, Time: time.Second})
+	if got.Action != "BUY_PROPERTY" {
+		t.Fatalf("picked %s (%s)", got.Action, got.Reason)
+	}
+	if options[1].Reason != "" {
+		t.Error("the options must not be modified")
+	}
+}
+
+func TestCandidates_BuyOrAuctionTheTileLandedOn(t *testing.T) {
+	game := &domain.GameState{
+		Status:            domain.GameStatusActive,
+		CurrentTurnID:     "BOT_1",
+		Dice:              [2]int{2, 3},
+		Board:             []domain.Tile{{PropertyID: "GO"}, {PropertyID: "P1", Name: "Plaza", Price: 100}},
+		PropertyOwnership: map[string]string{},
+	}
+	bot := &domain.PlayerState{UserID: "BOT_1", Position: 1, Balance: 500}
+	rules := coinRules{legal: map[string][]domain.LegalAction{"BOT_1": {
+		{Action: "BUY_PROPERTY", PropertyIDs: []string{"P1"}},
+		{Action: "START_AUCTION", PropertyIDs: []string{"P1"}},
+	}}}
+
+	options := candidates(rules, game, bot, &domain.BotAction{Action: "BUY_PROPERTY"})
+	if len(options) != 2 || options[0].Action != "BUY_PROPERTY" || options[1].Action != "START_AUCTION" {
+		t.Fatalf("candidates: %+v", options)
+	}
+
+	// Nothing to choose before rolling
+	game.Dice = [2]int{}
+	rules.legal["BOT_1"] = []domain.LegalAction{{Action: "ROLL_DICE"}}
+	if options := candidates(rules, game, bot, &domain.BotAction{Action: "ROLL_DICE"}); len(options) != 1 {
+		t.Errorf("candidates before rolling: %+v", options)
+	}
+}
+
+func TestCandidates_TradesMortgagesAndDutchClocks(t *testing.T) {
+	me, rival := "BOT_1", "BOT_2"
+	avenue := func(id string, owner *string) domain.Tile {
+		return domain.Tile{PropertyID: id, Name: id, Type: "PROPERTY", GroupIdentifier: "g1", Price: 100, RentBase: 10,
+			Rent1House: 50, HouseCost: 50, MortgageValue: 50, UnmortgageValue: 55, OwnerID: owner}
+	}
+	game := &domain.GameState{
+		Status:            domain.GameStatusActive,
+		CurrentTurnID:     me,
+		Dice:              [2]int{2, 3},
+		Board:             []domain.Tile{{PropertyID: "GO"}, avenue("P1", &me), avenue("P2", &rival)},
+		PropertyOwnership: map[string]string{"P1": me, "P2": rival},
+		Players: []*domain.PlayerState{
+			{UserID: me, Name: me, Balance: 1000, IsActive: true},
+			{UserID: rival, Name: rival, Balance: 1000, IsActive: true},
+		},
+	}
+	bot := game.Players[0]
+	rules := coinRules{legal: map[string][]domain.LegalAction{
+		me: {
+			{Action: "END_TURN"},
+			{Action: "MORTGAGE_PROPERTY", PropertyIDs: []string{"P1"}},
+			{Action: "INITIATE_TRADE", TargetIDs: []string{rival}},
+		},
+		rival: {{Action: "MORTGAGE_PROPERTY", PropertyIDs: []string{"P2"}}},
+	}}
+
+	// Completing the group is worth a cash offer, and the lone property can be mortgaged
+	found := make(map[string]bool)
+	for _, o := range candidates(rules, game, bot, &domain.BotAction{Action: "END_TURN"}) {
+		found[o.Action+" "+o.PropertyID] = true
+	}
+	if !found["INITIATE_TRADE "] || !found["MORTGAGE_PROPERTY P1"] {
+		t.Errorf("turn candidates: %v", found)
+	}
+
+	// Nothing the rules don't list: a rival's property with buildings in its group can't be asked for
+	rules.legal[rival] = nil
+	for _, o := range candidates(rules, game, bot, &domain.BotAction{Action: "END_TURN"}) {
+		if o.Action == "INITIATE_TRADE" {
+			t.Errorf("proposed %s for a property the rules won't trade", o.Payload)
+		}
+	}
+
+	// On a Dutch clock, taking the price is weighed against waiting
+	game.ActiveAuction = &domain.AuctionState{PropertyID: "P2", Format: domain.AuctionDutch, IsActive: true, StartPrice: 300, FloorPrice: 50}
+	rules.legal[me] = []domain.LegalAction{{Action: "BID", MinAmount: 250, MaxAmount: 250}, {Action: "PASS_AUCTION"}}
+	options := candidates(rules, game, bot, nil)
+	if len(options) != 2 || options[0] != wait || options[1].Action != "BID" || options[1].Amount != 250 {
+		t.Fatalf("Dutch candidates: %+v", options)
+	}
+	rules.odds = map[string]float64{"": 0.6, "BID": 0.4}
+	view := bots.View{Game: game, Bot: bot, Rules: rules}
+	if got := (Strategy{}).Decide(view); got != nil {
+		t.Errorf("waiting scored best but the bot did %+v", got)
+	}
+}
@@ -0,0 +1,90 @@
+// Package bots is where bot brains plug into the game. A Strategy sees the game from one bot's
+// seat and decides its next action; strategies register under the name a BotProfile uses in its
+// Strategy field, so a new brain is a package that calls Register from its init.
+package bots
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// Names of the built-in strategies
+const (
+	Heuristic = "HEURISTIC"
+	LLM       = "LLM"
+)
+
+// View is what a strategy decides from: the game, the bot's own seat and its personality.
+// Strategies only read it; the decided action goes through the same rules as a human's.
+type View struct {
+	Game    *domain.GameState
+	Bot     *domain.PlayerState
+	Profile domain.BotProfile
+	Rules   Rules // For strategies that look ahead; nil when the game can't be simulated
+}
+
+// Rules plays the game forward on copies of it, with the rules of a real game and without any
+// I/O (no database, websocket hub, LLM or bot delays), so strategies can look ahead
+type Rules interface {
+	// Clone copies a game for lookahead; the copy shares nothing with the original
+	Clone(game *domain.GameState) *domain.GameState
+	// Apply plays an action for a player, as if the player sent it
+	Apply(game *domain.GameState, userID string, action *domain.BotAction)
+	// LegalActions lists what a player can do right now, as the rules check it
+	LegalActions(game *domain.GameState, userID string) []domain.LegalAction
+	// Default is what the default strategy would do for a player
+	Default(game *domain.GameState, userID string) *domain.BotAction
+	// Playout plays a game on for a number of rounds with the default strategy for everyone
+	Playout(game *domain.GameState, rounds int)
+	// Score rates how well a player is doing, from 0 (bankrupt) to 1 (won the game)
+	Score(game *domain.GameState, userID string) float64
+}
+
+// Strategy decides a bot's next action. A nil action means it has nothing to do right now.
+type Strategy interface {
+	Decide(view View) *domain.BotAction
+}
+
+// StrategyFunc adapts a function to a Strategy
+type StrategyFunc func(view View) *domain.BotAction
+
+func (f StrategyFunc) Decide(view View) *domain.BotAction {
+	return f(view)
+}
+
+var (
+	mu         sync.RWMutex
+	strategies = make(map[string]Strategy)
+)
+
+// Register makes a strategy available under a name; registering a name again replaces it
+func Register(name string, strategy Strategy) {
+	if strategy == nil {
+		panic("bots: Register strategy is nil for " + name)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	strategies[name] = strategy
+}
+
+// Lookup returns the strategy registered under a name
+func Lookup(name string) (Strategy, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	strategy, ok := strategies[name]
+	return strategy, ok
+}
+
+// Names lists the registered strategies, sorted
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,94 @@
+package cards
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// Deck types
+const (
+	DeckChance    = "CHANCE"
+	DeckCommunity = "COMMUNITY"
+)
+
+// Validate checks a card row against the board it will be played on: its deck type, that its
+// effect parses, and that every tile type or property it moves to exists
+func Validate(card domain.Card, board []domain.Tile) error {
+	if card.Type != DeckChance && card.Type != DeckCommunity {
+		return errors.New("mazo desconocido: " + card.Type)
+	}
+	effect, err := Parse(card.Effect)
+	if err != nil {
+		return err
+	}
+	for _, c := range effect {
+		switch c.Destination {
+		case DestNearest:
+			if FindNext(board, 0, c.TileType) < 0 {
+				return errors.New("no hay casillas de tipo " + c.TileType)
+			}
+		case DestProperty:
+			if FindProperty(board, c.Property) < 0 {
+				return errors.New("propiedad desconocida: " + c.Property)
+			}
+		case DestRandom, DestLast:
+			if LastProperty(board) < 0 {
+				return errors.New("el tablero no tiene avenidas")
+			}
+		}
+	}
+	return nil
+}
+
+// Shuffle returns the IDs of a deck's cards in a random draw order
+func Shuffle(deck []domain.Card) []int {
+	ids := make([]int, len(deck))
+	for i, c := range deck {
+		ids[i] = c.ID
+	}
+	rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	return ids
+}
+
+// FindNext returns the board index of the next tile of a type after pos, -1 if there's none
+func FindNext(board []domain.Tile, pos int, tileType string) int {
+	for i := 1; i <= len(board); i++ {
+		idx := (pos + i) % len(board)
+		if board[idx].Type == tileType {
+			return idx
+		}
+	}
+	return -1
+}
+
+// FindProperty returns the board index of a property by ID or slug, -1 if it isn't on the board
+func FindProperty(board []domain.Tile, ref string) int {
+	for i, t := range board {
+		if t.Price > 0 && (t.PropertyID == ref || t.Slug == ref) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Avenues returns the board indexes of the buildable properties
+func Avenues(board []domain.Tile) []int {
+	var idx []int
+	for i, t := range board {
+		if t.Type == "PROPERTY" {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// LastProperty returns the board index of the last avenue, -1 if there's none
+func LastProperty(board []domain.Tile) int {
+	avenues := Avenues(board)
+	if len(avenues) == 0 {
+		return -1
+	}
+	return avenues[len(avenues)-1]
+}
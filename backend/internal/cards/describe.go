@@ -0,0 +1,79 @@
+package cards
+
+import (
+	"strconv"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+var targetNames = map[string]string{
+	TargetBank:    "el banco",
+	TargetEach:    "cada jugador",
+	TargetRichest: "el jugador más rico",
+	TargetPoorest: "el jugador más pobre",
+}
+
+var variableNames = map[string]string{
+	"balance":         "su saldo",
+	"properties":      "sus propiedades",
+	"houses":          "sus casas",
+	"hotels":          "sus hoteles",
+	"jail_free_cards": "sus tarjetas 'Sal de la Cárcel'",
+	"round":           "la ronda",
+}
+
+// Describe renders a clause in plain Spanish, for card previews
+func Describe(c Clause) string {
+	var msg string
+	switch c.Action {
+	case ActionCollect:
+		msg = "Cobra $" + strconv.Itoa(c.Amount) + " de " + targetNames[c.Target]
+	case ActionPay:
+		msg = "Paga $" + strconv.Itoa(c.Amount) + " a " + targetNames[c.Target]
+	case ActionRepair:
+		msg = "Paga $" + strconv.Itoa(c.HouseCost) + " por casa y $" + strconv.Itoa(c.HotelCost) + " por hotel"
+	case ActionKeep:
+		msg = "Guarda '" + domain.Items[c.Item].Name + "' en su inventario"
+	case ActionMove:
+		msg = describeMove(c)
+	}
+
+	if c.Condition != nil {
+		msg = "Si " + variableNames[c.Condition.Variable] + " " + c.Condition.Op + " " + strconv.Itoa(c.Condition.Value) + ": " + msg
+	}
+	return msg
+}
+
+func describeMove(c Clause) string {
+	var msg string
+	switch c.Destination {
+	case DestJail:
+		return "Va directo a la Cárcel"
+	case DestGo:
+		msg = "Avanza hasta la SALIDA"
+	case DestGoBonus:
+		msg = "Avanza hasta la SALIDA y cobra $500"
+	case DestRelative:
+		if c.Steps < 0 {
+			msg = "Retrocede " + strconv.Itoa(-c.Steps) + " casillas"
+		} else {
+			msg = "Avanza " + strconv.Itoa(c.Steps) + " casillas"
+		}
+	case DestNearest:
+		msg = "Avanza a la casilla " + c.TileType + " más cercana"
+	case DestRandom:
+		msg = "Avanza a una avenida al azar"
+	case DestLast:
+		msg = "Avanza a la última avenida"
+	case DestProperty:
+		msg = "Avanza hasta " + c.Property
+	}
+
+	switch c.Rent {
+	case RentMultiplied:
+		msg += "; si tiene dueño paga " + strconv.Itoa(c.Multiplier) + " veces la renta"
+	case RentDice:
+		msg += "; si tiene dueño tira los dados y paga " + strconv.Itoa(c.Multiplier) + " veces el resultado"
+	}
+	return msg
+}
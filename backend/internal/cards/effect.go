@@ -0,0 +1,348 @@
+// Package cards parses and validates the effect language of Chance and Community cards.
+//
+// An effect is one or more clauses separated by ";", applied in order:
+//
+//	clause      := [condition "?"] action ["@" target]
+//	condition   := variable ("<" | "<=" | ">" | ">=" | "=" | "!=") integer
+//	action      := "collect:" amount | "pay:" amount | "repair:" house ":" hotel
+//	             | "move:" destination [":" rent] | "keep:" item
+//	destination := "GO" | "GO_BONUS" | "JAIL" | ("+" | "-") steps
+//	             | "nearest_" tiletype | "random_property" | "last_property" | property
+//	rent        := "x" multiplier | "dice" multiplier
+//	target      := "bank" | "each" | "richest" | "poorest"
+//
+// e.g. "move:nearest_railroad:x2", "properties>=3?pay:25@each" or "collect:50;move:GO".
+// "collect_all:N" and "pay_all:N" are kept as aliases of "collect:N@each" and "pay:N@each",
+// and "jail_free" of "keep:JAIL_FREE".
+package cards
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// Actions
+const (
+	ActionCollect = "collect" // Receive money from the target
+	ActionPay     = "pay"     // Pay money to the target
+	ActionRepair  = "repair"  // Pay per house and per hotel owned
+	ActionMove    = "move"    // Move to a destination
+	ActionKeep    = "keep"    // Keep an inventory item
+)
+
+// Targets of collect and pay
+const (
+	TargetBank    = "bank"    // The bank (fines go to the Free Parking pot under the jackpot rule)
+	TargetEach    = "each"    // Every other active player
+	TargetRichest = "richest" // The other active player with the highest balance
+	TargetPoorest = "poorest" // The other active player with the lowest balance
+)
+
+// Destinations of move
+const (
+	DestGo       = "GO"       // Advance to GO, collecting the salary
+	DestGoBonus  = "GO_BONUS" // Advance to GO, collecting the landing bonus
+	DestJail     = "JAIL"     // Go straight to jail
+	DestRelative = "RELATIVE" // Move Steps tiles (backwards if negative)
+	DestNearest  = "NEAREST"  // Advance to the next tile of TileType
+	DestRandom   = "RANDOM_PROPERTY"
+	DestLast     = "LAST_PROPERTY"
+	DestProperty = "PROPERTY" // Advance to the property in Property (ID or slug)
+)
+
+// Rent rules on arrival after a move
+const (
+	RentNormal     = ""
+	RentMultiplied = "MULTIPLIED" // Normal rent times Multiplier
+	RentDice       = "DICE"       // A fresh dice roll times Multiplier
+)
+
+// Variables a condition can test
+var conditionVariables = map[string]bool{
+	"balance":         true,
+	"properties":      true,
+	"houses":          true,
+	"hotels":          true,
+	"jail_free_cards": true,
+	"round":           true,
+}
+
+// Condition gates a clause on the state of the player drawing the card
+type Condition struct {
+	Variable string `json:"variable"`
+	Op       string `json:"op"`
+	Value    int    `json:"value"`
+}
+
+// Clause is a single parsed step of an effect
+type Clause struct {
+	Condition *Condition `json:"condition,omitempty"`
+	Action    string     `json:"action"`
+
+	Amount int    `json:"amount,omitempty"` // collect, pay
+	Target string `json:"target,omitempty"` // collect, pay
+
+	Destination string `json:"destination,omitempty"` // move
+	Steps       int    `json:"steps,omitempty"`       // move RELATIVE
+	TileType    string `json:"tile_type,omitempty"`   // move NEAREST
+	Property    string `json:"property,omitempty"`    // move PROPERTY
+	Rent        string `json:"rent,omitempty"`        // move: rent rule on arrival
+	Multiplier  int    `json:"multiplier,omitempty"`  // move: rent multiplier
+
+	HouseCost int `json:"house_cost,omitempty"` // repair
+	HotelCost int `json:"hotel_cost,omitempty"` // repair
+
+	Item string `json:"item,omitempty"` // keep
+}
+
+// Effect is a parsed card effect
+type Effect []Clause
+
+// Parse parses an effect string
+func Parse(src string) (Effect, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, errors.New("efecto vacío")
+	}
+	var effect Effect
+	for _, part := range strings.Split(src, ";") {
+		clause, err := parseClause(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", part, err)
+		}
+		effect = append(effect, clause)
+	}
+	return effect, nil
+}
+
+func parseClause(src string) (Clause, error) {
+	var c Clause
+	if i := strings.Index(src, "?"); i >= 0 {
+		cond, err := parseCondition(src[:i])
+		if err != nil {
+			return c, err
+		}
+		c.Condition = &cond
+		src = src[i+1:]
+	}
+	if i := strings.LastIndex(src, "@"); i >= 0 {
+		c.Target = src[i+1:]
+		src = src[:i]
+	}
+
+	parts := strings.Split(src, ":")
+	c.Action, parts = parts[0], parts[1:]
+
+	switch c.Action {
+	case "collect_all", "pay_all":
+		if c.Target != "" {
+			return c, errors.New(c.Action + " no admite destinatario")
+		}
+		c.Action = strings.TrimSuffix(c.Action, "_all")
+		c.Target = TargetEach
+		fallthrough
+	case ActionCollect, ActionPay:
+		if len(parts) != 1 {
+			return c, errors.New(c.Action + " necesita un monto")
+		}
+		amount, err := positive(parts[0])
+		if err != nil {
+			return c, err
+		}
+		c.Amount = amount
+		switch c.Target {
+		case "":
+			c.Target = TargetBank
+		case TargetBank, TargetEach, TargetRichest, TargetPoorest:
+		default:
+			return c, errors.New("destinatario desconocido: " + c.Target)
+		}
+		return c, nil
+	}
+
+	if c.Target != "" {
+		return c, errors.New(c.Action + " no admite destinatario")
+	}
+
+	switch c.Action {
+	case "jail_free":
+		if len(parts) != 0 {
+			return c, errors.New("jail_free no lleva argumentos")
+		}
+		c.Action, c.Item = ActionKeep, domain.ItemJailFree
+	case ActionKeep:
+		if len(parts) != 1 {
+			return c, errors.New("keep necesita un objeto")
+		}
+		if _, known := domain.Items[parts[0]]; !known {
+			return c, errors.New("objeto desconocido: " + parts[0])
+		}
+		c.Item = parts[0]
+	case ActionRepair:
+		if len(parts) != 2 {
+			return c, errors.New("repair necesita el costo por casa y por hotel")
+		}
+		house, err1 := strconv.Atoi(parts[0])
+		hotel, err2 := strconv.Atoi(parts[1])
+		if err1 != nil || err2 != nil || house < 0 || hotel < 0 || house+hotel == 0 {
+			return c, errors.New("costos de reparación inválidos")
+		}
+		c.HouseCost, c.HotelCost = house, hotel
+	case ActionMove:
+		if len(parts) < 1 || len(parts) > 2 {
+			return c, errors.New("move necesita un destino")
+		}
+		if err := parseDestination(&c, parts[0]); err != nil {
+			return c, err
+		}
+		if len(parts) == 2 {
+			if err := parseRent(&c, parts[1]); err != nil {
+				return c, err
+			}
+		}
+	default:
+		return c, errors.New("acción desconocida: " + c.Action)
+	}
+	return c, nil
+}
+
+func parseCondition(src string) (Condition, error) {
+	// Two-character operators first so "<=" isn't read as "<"
+	for _, op := range []string{"<=", ">=", "!=", "<", ">", "="} {
+		i := strings.Index(src, op)
+		if i < 0 {
+			continue
+		}
+		variable := strings.TrimSpace(src[:i])
+		if !conditionVariables[variable] {
+			return Condition{}, errors.New("variable desconocida: " + variable)
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(src[i+len(op):]))
+		if err != nil {
+			return Condition{}, errors.New("valor inválido en la condición " + src)
+		}
+		return Condition{Variable: variable, Op: op, Value: value}, nil
+	}
+	return Condition{}, errors.New("condición inválida: " + src)
+}
+
+func parseDestination(c *Clause, dest string) error {
+	switch {
+	case dest == DestGo || dest == DestGoBonus || dest == DestJail:
+		c.Destination = dest
+	case dest == "random_property":
+		c.Destination = DestRandom
+	case dest == "last_property":
+		c.Destination = DestLast
+	case strings.HasPrefix(dest, "nearest_"):
+		c.Destination = DestNearest
+		c.TileType = strings.ToUpper(strings.TrimPrefix(dest, "nearest_"))
+	case strings.HasPrefix(dest, "+") || strings.HasPrefix(dest, "-"):
+		steps, err := strconv.Atoi(dest)
+		if err != nil || steps == 0 {
+			return errors.New("desplazamiento inválido: " + dest)
+		}
+		c.Destination = DestRelative
+		c.Steps = steps
+	case dest == "":
+		return errors.New("move necesita un destino")
+	default:
+		c.Destination = DestProperty
+		c.Property = dest
+	}
+	return nil
+}
+
+func parseRent(c *Clause, rule string) error {
+	if c.Destination == DestJail {
+		return errors.New("ir a la cárcel no cobra renta")
+	}
+	var digits string
+	switch {
+	case strings.HasPrefix(rule, "x"):
+		c.Rent, digits = RentMultiplied, rule[1:]
+	case strings.HasPrefix(rule, "dice"):
+		c.Rent, digits = RentDice, rule[4:]
+	default:
+		return errors.New("regla de renta desconocida: " + rule)
+	}
+	multiplier, err := positive(digits)
+	if err != nil {
+		return err
+	}
+	c.Multiplier = multiplier
+	return nil
+}
+
+func positive(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, errors.New("monto inválido: " + s)
+	}
+	return n, nil
+}
+
+// Holds reports whether the condition is met by the player drawing the card
+func (c *Condition) Holds(game *domain.GameState, player *domain.PlayerState) bool {
+	var value int
+	switch c.Variable {
+	case "balance":
+		value = player.Balance
+	case "jail_free_cards":
+		value = player.Inventory[domain.ItemJailFree]
+	case "round":
+		value = game.Round
+	default:
+		for _, t := range game.Board {
+			if t.OwnerID == nil || *t.OwnerID != player.UserID {
+				continue
+			}
+			switch c.Variable {
+			case "properties":
+				value++
+			case "houses":
+				if t.BuildingCount < 5 {
+					value += t.BuildingCount
+				}
+			case "hotels":
+				if t.BuildingCount == 5 {
+					value++
+				}
+			}
+		}
+	}
+
+	switch c.Op {
+	case "<":
+		return value < c.Value
+	case "<=":
+		return value <= c.Value
+	case ">":
+		return value > c.Value
+	case ">=":
+		return value >= c.Value
+	case "=":
+		return value == c.Value
+	case "!=":
+		return value != c.Value
+	}
+	return false
+}
+
+// RepairCost is what a repair clause charges a player for their buildings
+func (c *Clause) RepairCost(game *domain.GameState, userID string) int {
+	total := 0
+	for _, t := range game.Board {
+		if t.OwnerID != nil && *t.OwnerID == userID {
+			if t.BuildingCount == 5 {
+				total += c.HotelCost
+			} else {
+				total += t.BuildingCount * c.HouseCost
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,55 @@
+package cards
+
+import (
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func TestParse_LegacyAndComposedEffects(t *testing.T) {
+	effect, err := Parse("collect_all:10")
+	if err != nil || len(effect) != 1 || effect[0].Action != ActionCollect || effect[0].Target != TargetEach || effect[0].Amount != 10 {
+		t.Fatalf("collect_all: %+v, %v", effect, err)
+	}
+
+	effect, err = Parse("houses>0?repair:25:100; move:nearest_railroad:x2")
+	if err != nil || len(effect) != 2 {
+		t.Fatalf("composed effect: %+v, %v", effect, err)
+	}
+	if c := effect[0]; c.Condition == nil || c.Condition.Variable != "houses" || c.Condition.Op != ">" || c.HouseCost != 25 || c.HotelCost != 100 {
+		t.Errorf("repair clause: %+v", c)
+	}
+	if c := effect[1]; c.Destination != DestNearest || c.TileType != "RAILROAD" || c.Rent != RentMultiplied || c.Multiplier != 2 {
+		t.Errorf("move clause: %+v", c)
+	}
+
+	for _, bad := range []string{"", "pay:-5", "pay:10@nobody", "repair:25", "move:JAIL:x2", "fly:GO", "luck>3?collect:5", "jail_free@each"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("%q should not parse", bad)
+		}
+	}
+}
+
+func TestValidate_ChecksDestinationsAgainstBoard(t *testing.T) {
+	board := []domain.Tile{
+		{Type: "GO"},
+		{Type: "PROPERTY", PropertyID: "p1", Slug: "av-ossa", Price: 100},
+		{Type: "RAILROAD", PropertyID: "r1", Price: 200},
+	}
+	valid := []string{"move:av-ossa", "move:p1", "move:nearest_railroad", "move:last_property", "pay:50@richest"}
+	for _, effect := range valid {
+		if err := Validate(domain.Card{Type: DeckChance, Effect: effect}, board); err != nil {
+			t.Errorf("%q: %v", effect, err)
+		}
+	}
+	invalid := []domain.Card{
+		{Type: DeckChance, Effect: "move:av-unknown"},
+		{Type: DeckChance, Effect: "move:nearest_utility"},
+		{Type: "LUCK", Effect: "collect:10"},
+	}
+	for _, card := range invalid {
+		if err := Validate(card, board); err == nil {
+			t.Errorf("%+v should be invalid", card)
+		}
+	}
+}
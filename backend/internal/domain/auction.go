@@ -2,9 +2,70 @@ package domain
 
 import "time"
 
+// Auction formats
+const (
+	AuctionEnglish      = "ENGLISH"             // Open ascending bids
+	AuctionSealedFirst  = "SEALED_FIRST_PRICE"  // Hidden bids, the winner pays their bid
+	AuctionSealedSecond = "SEALED_SECOND_PRICE" // Hidden bids, the winner pays the second highest bid (Vickrey)
+	AuctionDutch        = "DUTCH"               // The price drops until someone accepts it
+)
+
+// Dutch auction clock: the price falls from StartPrice to FloorPrice in DutchDropSteps drops
+const (
+	DutchDropSteps    = 15
+	DutchDropInterval = 2 * time.Second
+)
+
+// Auction bidding rules
+const (
+	AuctionMinIncrement = 10 // Minimum raise over the highest bid, and minimum opening bid
+	AuctionOpeningPct   = 10 // Opening bid as a percentage of the list price
+	AuctionDurationSecs = 30
+)
+
+// Auction outcomes, as stored in the auction records
+const (
+	AuctionSold   = "SOLD"
+	AuctionUnsold = "UNSOLD"
+)
+
+// ValidAuctionFormat reports whether format names a supported auction format
+func ValidAuctionFormat(format string) bool {
+	switch format {
+	case AuctionEnglish, AuctionSealedFirst, AuctionSealedSecond, AuctionDutch:
+		return true
+	}
+	return false
+}
+
+// BankAuctionRules configures the bank auctioning its unowned properties
+type BankAuctionRules struct {
+	EveryRounds    int  `json:"every_rounds"`    // Auction one unowned property every N table rounds (0 = never)
+	Foreclosures   bool `json:"foreclosures"`    // Auction properties released by a bankruptcy right away
+	ReservePercent int  `json:"reserve_percent"` // Reserve price as a percentage of Tile.Price (0 = DefaultBankReservePct)
+}
+
+// DefaultBankReservePct is the default reserve price of bank auctions
+const DefaultBankReservePct = 50
+
+// AuctionBid is an entry in an auction's bid history
+type AuctionBid struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Amount     int    `json:"amount"`
+	Timestamp  int64  `json:"timestamp"`
+}
+
 // AuctionState represents an active auction in the game
 type AuctionState struct {
+	ID            string          `json:"id"` // AU-<sequence>, unique within the game
 	PropertyID    string          `json:"property_id"`
+	Format        string          `json:"format"` // One of the Auction* formats ("" = ENGLISH)
+	StartedBy     string          `json:"started_by"`
+	StartTime     int64           `json:"start_time"` // Unix seconds, also starts the Dutch clock
+	OpeningBid    int             `json:"opening_bid"`
+	MinIncrement  int             `json:"min_increment"`
+	Bids          []AuctionBid    `json:"bids"` // Full bid history (hidden until the end in sealed formats)
 	HighestBid    int             `json:"highest_bid"`
 	BidderID      string          `json:"bidder_id"`     // UserID of highest bidder
 	BidderName    string          `json:"bidder_name"`   // Name of highest bidder
@@ -12,4 +73,28 @@ type AuctionState struct {
 	LastBidTime   int64           `json:"last_bid_time"` // Timestamp of the last bid (Unix seconds)
 	IsActive      bool            `json:"is_active"`
 	PassedPlayers map[string]bool `json:"passed_players"` // Set of UserIDs who passed
+
+	// Sealed formats: bids stay hidden from the clients until the envelopes are opened
+	SealedBids map[string]int `json:"sealed_bids,omitempty"` // UserID -> bid
+	Bidders    []string       `json:"bidders,omitempty"`     // Who has submitted a bid
+
+	// Dutch format
+	StartPrice int `json:"start_price,omitempty"`
+	FloorPrice int `json:"floor_price,omitempty"`
+}
+
+// AuctionRecord is the stored outcome of a finished auction
+type AuctionRecord struct {
+	AuctionID    string       `json:"auction_id"`
+	PropertyID   string       `json:"property_id"`
+	PropertyName string       `json:"property_name"`
+	Format       string       `json:"format"`
+	OpeningBid   int          `json:"opening_bid"`
+	WinnerID     string       `json:"winner_id,omitempty"`
+	WinnerName   string       `json:"winner_name,omitempty"`
+	Price        int          `json:"price"`
+	Status       string       `json:"status"` // SOLD, UNSOLD
+	Bids         []AuctionBid `json:"bids"`
+	StartedAt    time.Time    `json:"started_at"`
+	EndedAt      time.Time    `json:"ended_at"`
 }
@@ -0,0 +1,71 @@
+package domain
+
+// Tile types with a fixed rule, placed by the board's corners
+const (
+	TileGo          = "GO"
+	TileJail        = "JAIL"
+	TileFreeParking = "FREE_PARKING"
+	TileGoToJail    = "GO_TO_JAIL"
+)
+
+// Special tile types charging a tax
+const (
+	TileIncomeTax = "TAX"
+	TileLuxuryTax = "LUXURY_TAX"
+)
+
+// BoardDefinition is a named board a host can pick for a game. The classic board (ID 0) is built
+// from the properties and board_layout tables; custom boards are stored as JSON and can be
+// imported and exported as is.
+type BoardDefinition struct {
+	ID          int             `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Size        int             `json:"size"` // Number of tiles, len(Tiles)
+	Corners     BoardCorners    `json:"corners"`
+	Groups      []PropertyGroup `json:"groups,omitempty"`
+	Tiles       []BoardTile     `json:"tiles"` // In board order, starting at index 0
+}
+
+// BoardCorners places the tiles with a fixed rule, by board index
+type BoardCorners struct {
+	Go          int `json:"go"`
+	Jail        int `json:"jail"`
+	FreeParking int `json:"free_parking"`
+	GoToJail    int `json:"go_to_jail"`
+}
+
+// PropertyGroup is a color group or neighborhood, referenced by Property.GroupID
+type PropertyGroup struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// BoardTile is a tile as authored in a board definition. Purchasable tiles carry their
+// property; its ID defaults to its slug on custom boards.
+type BoardTile struct {
+	Type     string    `json:"type"` // PROPERTY, RAILROAD, UTILITY, CHANCE, COMMUNITY, TAX, LUXURY_TAX, ...
+	Name     string    `json:"name,omitempty"`
+	Property *Property `json:"property,omitempty"`
+}
+
+// BoardSummary lists a board without its tiles
+type BoardSummary struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Size        int    `json:"size"`
+	IsDefault   bool   `json:"is_default"` // Used by games that don't pick a board
+	Valid       bool   `json:"valid"`      // Games only start on boards without problems
+}
+
+// TilePosition returns the index of the first tile of a type, -1 if the board has none
+func TilePosition(board []Tile, tileType string) int {
+	for i, t := range board {
+		if t.Type == tileType {
+			return i
+		}
+	}
+	return -1
+}
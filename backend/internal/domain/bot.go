@@ -13,7 +13,11 @@ type BotProfile struct {
 	Aggression float64 `json:"aggression"`
 	// NegotiationSkill: Affects trade logic complexity
 	NegotiationSkill float64 `json:"negotiation_skill"`
-	Strategy         string  `json:"strategy"` // "LLM" or "HEURISTIC"
+	Strategy         string  `json:"strategy"` // Registered bots.Strategy: "LLM", "HEURISTIC", ...
+	// Search budget per decision for strategies that look ahead, 0 = their default. Fewer
+	// simulations make an easier bot.
+	SearchIterations int `json:"search_iterations,omitempty"`
+	SearchTimeMs     int `json:"search_time_ms,omitempty"`
 }
 
 // BotAction represents the decision made by the AI
@@ -74,6 +78,28 @@ var BotPersonalities = []BotProfile{
 		NegotiationSkill: 0.9,
 		Strategy:         "LLM",
 	},
+	{
+		ID:               "strategist",
+		Name:             "El Estratega",
+		Description:      "Simula cientos de partidas antes de cada decisión. Difícil de vencer, sin LLM.",
+		RiskTolerance:    0.5,
+		Aggression:       0.6,
+		NegotiationSkill: 0.5,
+		Strategy:         "MCTS",
+		SearchIterations: 400,
+		SearchTimeMs:     2000,
+	},
+	{
+		ID:               "apprentice",
+		Name:             "El Aprendiz",
+		Description:      "Planifica con pocas simulaciones. Un rival a medida para aprender.",
+		RiskTolerance:    0.5,
+		Aggression:       0.5,
+		NegotiationSkill: 0.3,
+		Strategy:         "MCTS",
+		SearchIterations: 40,
+		SearchTimeMs:     300,
+	},
 }
 
 func GetBotProfile(id string) BotProfile {
@@ -0,0 +1,42 @@
+package domain
+
+// Contract types that can be agreed as part of a trade
+const (
+	ContractRentImmunity = "RENT_IMMUNITY" // Beneficiary pays no rent on the grantor's properties of a group
+	ContractRevenueShare = "REVENUE_SHARE" // Beneficiary receives a percentage of the rent collected on a property
+	ContractPayOnGo      = "PAY_ON_GO"     // Grantor pays an amount the next time they pass GO
+)
+
+// MaxContractRounds caps the duration of a contract
+const MaxContractRounds = 20
+
+// ContractTerm is a contract clause proposed in a trade offer
+type ContractTerm struct {
+	Type          string `json:"type"`
+	GrantorID     string `json:"grantor_id"`            // Who gives the benefit
+	BeneficiaryID string `json:"beneficiary_id"`        // Who receives it
+	GroupID       string `json:"group_id,omitempty"`    // RENT_IMMUNITY
+	PropertyID    string `json:"property_id,omitempty"` // REVENUE_SHARE
+	Percent       int    `json:"percent,omitempty"`     // REVENUE_SHARE
+	Amount        int    `json:"amount,omitempty"`      // PAY_ON_GO
+	Landings      int    `json:"landings,omitempty"`    // RENT_IMMUNITY: number of free landings (0 = unlimited within Rounds)
+	Rounds        int    `json:"rounds,omitempty"`      // Duration in table rounds (0 = until used up)
+}
+
+// Contract is a term in force, public to every player
+type Contract struct {
+	ID                string `json:"id"`
+	TradeID           string `json:"trade_id"`
+	Type              string `json:"type"`
+	GrantorID         string `json:"grantor_id"`
+	GrantorName       string `json:"grantor_name"`
+	BeneficiaryID     string `json:"beneficiary_id"`
+	BeneficiaryName   string `json:"beneficiary_name"`
+	GroupID           string `json:"group_id,omitempty"`
+	PropertyID        string `json:"property_id,omitempty"`
+	Percent           int    `json:"percent,omitempty"`
+	Amount            int    `json:"amount,omitempty"`
+	RemainingLandings int    `json:"remaining_landings,omitempty"` // 0 = no landing limit
+	ExpiresRound      int    `json:"expires_round,omitempty"`      // Game round at which it ends, 0 = no time limit
+	TotalValue        int    `json:"total_value"`                  // Rent waived or money paid so far
+}
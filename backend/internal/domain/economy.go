@@ -0,0 +1,19 @@
+package domain
+
+// Central bank policy parameters (percent per round)
+const (
+	NeutralBaseRate     = 5   // Base rate when inflation is on target
+	InflationTarget     = 1.0 // Target price growth per round
+	MinBaseRate         = 1
+	MaxBaseRate         = 25
+	MaxInflationPerTurn = 5.0 // Cap on the price growth of a single round
+)
+
+// Economy is the per-game macroeconomic model driven by the simulated central bank
+type Economy struct {
+	BaseRate      int     `json:"base_rate"`       // % added to loan rates (replaces the neutral rate)
+	Inflation     float64 `json:"inflation"`       // Price growth of the last round (%)
+	PriceIndex    float64 `json:"price_index"`     // Cumulative price level, 1.0 at game start
+	MoneySupply   int     `json:"money_supply"`    // Balances, savings and outstanding loans at the last update
+	LastRateRound int     `json:"last_rate_round"` // Round of the last rate move
+}
@@ -1,24 +1,43 @@
 package domain
 
 type GameState struct {
-	GameID            string            `json:"game_id"`
-	Players           []*PlayerState    `json:"players"`
-	HostID            string            `json:"host_id"` // Tracks the creator of the game
-	Board             []Tile            `json:"board"`
-	CurrentTurnID     string            `json:"current_turn_id"` // UserID
-	Status            string            `json:"status"`          // WAITING, ROLLING_ORDER, ACTIVE, FINISHED
-	Dice              [2]int            `json:"dice"`
-	LastAction        string            `json:"last_action"` // Log description
-	ActiveAuction     *AuctionState     `json:"active_auction,omitempty"`
-	ActiveTrade       *TradeOffer       `json:"active_trade,omitempty"`
-	PropertyOwnership map[string]string `json:"property_ownership"` // PropertyID -> OwnerUserID
-	TileVisits        map[int]int       `json:"tile_visits"`        // TileIndex -> VisitCount
-	Logs              []EventLog        `json:"logs"`
-	TurnOrder         []string          `json:"turn_order"`            // UserIDs in order
-	OrderRolls        map[string]int    `json:"order_rolls,omitempty"` // UserID -> dice roll for turn order
-	DrawnCard         *Card             `json:"drawn_card,omitempty"`
-	PendingRent       *PendingRent      `json:"pending_rent,omitempty"`  // Manual rent collection
-	ChatMessages      []ChatMessage     `json:"chat_messages,omitempty"` // In-game chat
+	GameID            string                 `json:"game_id"`
+	Players           []*PlayerState         `json:"players"`
+	HostID            string                 `json:"host_id"` // Tracks the creator of the game
+	Board             []Tile                 `json:"board"`
+	CurrentTurnID     string                 `json:"current_turn_id"` // UserID
+	Status            string                 `json:"status"`          // WAITING, ROLLING_ORDER, ACTIVE, FINISHED
+	Dice              [2]int                 `json:"dice"`
+	LastAction        string                 `json:"last_action"` // Log description
+	ActiveAuction     *AuctionState          `json:"active_auction,omitempty"`
+	NextAuctionID     int                    `json:"next_auction_id,omitempty"`
+	BankAuctionQueue  []string               `json:"bank_auction_queue,omitempty"` // Properties the bank will auction next
+	Trades            map[string]*TradeOffer `json:"trades,omitempty"`             // Open trade offers by ID
+	TradeHistory      []*TradeOffer          `json:"trade_history,omitempty"`      // Closed offers, most recent last
+	NextTradeID       int                    `json:"next_trade_id"`
+	PropertyOwnership map[string]string      `json:"property_ownership"`   // PropertyID -> OwnerUserID
+	CostBasis         map[string]int         `json:"cost_basis,omitempty"` // PropertyID -> acquisition cost (capital gains)
+	TileVisits        map[int]int            `json:"tile_visits"`          // TileIndex -> VisitCount
+	Logs              []EventLog             `json:"logs"`
+	TurnOrder         []string               `json:"turn_order"`            // UserIDs in order
+	OrderRolls        map[string]int         `json:"order_rolls,omitempty"` // UserID -> dice roll for turn order
+	DrawnCard         *Card                  `json:"drawn_card,omitempty"`
+	CardDecks         map[string][]int       `json:"card_decks,omitempty"`    // Deck type -> card IDs left to draw, in order
+	Cards             []Card                 `json:"cards,omitempty"`         // The deck as it was when the game started
+	PendingRent       *PendingRent           `json:"pending_rent,omitempty"`  // Manual rent collection
+	ChatMessages      []ChatMessage          `json:"chat_messages,omitempty"` // In-game chat
+	Settings          GameSettings           `json:"settings"`
+	StockMarket       *StockMarket           `json:"stock_market,omitempty"` // Only when Settings.StockMarket
+	Economy           *Economy               `json:"economy,omitempty"`      // Only when Settings.CentralBank
+	Round             int                    `json:"round"`                  // Completed table rounds
+	Standings         []Standing             `json:"standings,omitempty"`    // Final ranking once FINISHED
+	PendingTax        *PendingTax            `json:"pending_tax,omitempty"`  // Income tax awaiting the player's choice
+	FreeParkingPot    int                    `json:"free_parking_pot"`       // Collected by whoever lands on FREE_PARKING
+	Treasury          *Treasury              `json:"treasury,omitempty"`     // Bank inflows and outflows
+	Ledger            *Ledger                `json:"ledger,omitempty"`       // Double-entry book of every balance change
+	Contracts         []*Contract            `json:"contracts,omitempty"`    // Trade contracts in force, visible to everyone
+	NextContractID    int                    `json:"next_contract_id,omitempty"`
+	NextPolicyID      int                    `json:"next_policy_id,omitempty"`
 }
 
 type PendingRent struct {
@@ -39,10 +58,21 @@ type ChatMessage struct {
 
 type Card struct {
 	ID          int    `json:"id"`
+	DeckID      int    `json:"deck_id,omitempty"`
 	Type        string `json:"type"` // CHANCE, COMMUNITY
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Effect      string `json:"effect"` // e.g. "move:10", "pay:50", "collect:200"
+	Enabled     bool   `json:"enabled"`
+}
+
+// CardDeck is a named set of Chance and Community cards a host can pick for a game
+type CardDeck struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CardCount   int    `json:"card_count"` // Enabled cards
+	IsDefault   bool   `json:"is_default"` // Used by games that don't pick a deck
 }
 
 type EventLog struct {
@@ -60,34 +90,27 @@ const (
 	GameStatusFinished     = "FINISHED"
 )
 
-type TradeOffer struct {
-	ID                string   `json:"id"`
-	OffererID         string   `json:"offerer_id"`
-	OffererName       string   `json:"offerer_name"`
-	TargetID          string   `json:"target_id"`
-	TargetName        string   `json:"target_name"`
-	OfferPropeties    []string `json:"offer_properties"`
-	OfferCash         int      `json:"offer_cash"`
-	RequestProperties []string `json:"request_properties"`
-	RequestCash       int      `json:"request_cash"`
-	Status            string   `json:"status"` // PENDING, ACCEPTED, REJECTED
-}
-
 type PlayerState struct {
-	UserID           string         `json:"user_id"`
-	Name             string         `json:"name"`
-	TokenColor       string         `json:"token_color"`
-	Balance          int            `json:"balance"`
-	Position         int            `json:"position"` // 0-63 (assuming 17x17 board loop)
-	InJail           bool           `json:"in_jail"`
-	JailTurns        int            `json:"jail_turns"` // Number of turns spent in jail without rolling doubles
-	IsActive         bool           `json:"is_active"`
-	Loan             int            `json:"loan"`
-	Credit           *CreditProfile `json:"credit,omitempty"`
-	TileVisits       map[int]int    `json:"tile_visits"` // TileIndex -> VisitCount for personal heatmap
-	IsBot            bool           `json:"is_bot"`
-	BotPersonalityID string         `json:"bot_personality_id,omitempty"`
-	TokenShape       string         `json:"token_shape"` // CUBE, PYRAMID, CYLINDER, STAR, etc.
+	UserID           string             `json:"user_id"`
+	Name             string             `json:"name"`
+	TokenColor       string             `json:"token_color"`
+	Balance          int                `json:"balance"`
+	Position         int                `json:"position"` // Board index, 0 to len(Board)-1
+	InJail           bool               `json:"in_jail"`
+	JailTurns        int                `json:"jail_turns"` // Number of turns spent in jail without rolling doubles
+	IsActive         bool               `json:"is_active"`
+	Loan             int                `json:"loan"`
+	Inventory        Inventory          `json:"inventory,omitempty"`          // Cards and items held
+	Savings          int                `json:"savings"`                      // Deposit account balance
+	SavingsLockRound int                `json:"savings_lock_round,omitempty"` // Round until which withdrawals pay a penalty
+	TaxLedger        []TaxEntry         `json:"tax_ledger,omitempty"`
+	TaxesPaid        int                `json:"taxes_paid"`
+	Credit           *CreditProfile     `json:"credit,omitempty"`
+	TileVisits       map[int]int        `json:"tile_visits"` // TileIndex -> VisitCount for personal heatmap
+	IsBot            bool               `json:"is_bot"`
+	BotPersonalityID string             `json:"bot_personality_id,omitempty"`
+	TokenShape       string             `json:"token_shape"`        // CUBE, PYRAMID, CYLINDER, STAR, etc.
+	Policies         []*InsurancePolicy `json:"policies,omitempty"` // Active insurance policies
 	// Bot cooldowns (not serialized to frontend)
 	LastBotChatTime  int64 `json:"-"` // Unix timestamp of last chat message
 	LastBotTradeTime int64 `json:"-"` // Unix timestamp of last trade proposal
@@ -109,6 +132,7 @@ type Tile struct {
 	Name            string  `json:"name"`
 	RentRule        string  `json:"rent_rule"`
 	PropertyID      string  `json:"property_id,omitempty"` // UUID
+	Slug            string  `json:"slug,omitempty"`        // Readable property key, e.g. "av-la-estrella"
 	OwnerID         *string `json:"owner_id,omitempty"`
 	Price           int     `json:"price,omitempty"`
 	Rent            int     `json:"rent,omitempty"`
@@ -130,12 +154,6 @@ type Tile struct {
 	IsMortgaged     bool    `json:"is_mortgaged"`
 }
 
-// Initial board size for 17x17 square loop
-// 4 sides * 16 spaces + 4 corners? No.
-// If side length is 17 tiles.
-// Top: 17
-// Right: 17
-// Bottom: 17
-// Left: 17
-// Total tiles = 17 + 15 + 17 + 15 = 64 tiles.
+// BoardSize is the number of tiles of the classic board: a 17x17 square loop,
+// 17 + 15 + 17 + 15 = 64 tiles. Custom boards set their own size.
 const BoardSize = 64
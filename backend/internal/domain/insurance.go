@@ -0,0 +1,37 @@
+package domain
+
+// Insurance policy types
+const (
+	InsuranceRent   = "RENT"   // Covers a percentage of rent paid above a threshold
+	InsuranceRepair = "REPAIR" // Covers "repair:" card effects
+)
+
+// Insurance pricing rules
+const (
+	InsuranceMaxRounds   = 10
+	InsuranceMarginPct   = 25 // Bank margin over the expected loss
+	InsuranceMinPremium  = 10
+	InsuranceMaxCoverage = 90 // Max % of a loss a policy can cover
+)
+
+// InsurancePolicy is a contract sold by the bank to a player
+type InsurancePolicy struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`             // RENT, REPAIR
+	CoveragePercent int    `json:"coverage_percent"` // % of the covered loss paid by the bank
+	Threshold       int    `json:"threshold"`        // RENT: only rent above this amount is covered
+	Premium         int    `json:"premium"`          // Paid upfront
+	StartRound      int    `json:"start_round"`
+	EndRound        int    `json:"end_round"` // Expires when the holder's round passes this value
+	ClaimsPaid      int    `json:"claims_paid"`
+}
+
+// InsuranceQuote is the price the bank offers for a policy
+type InsuranceQuote struct {
+	Type            string `json:"type"`
+	Rounds          int    `json:"rounds"`
+	CoveragePercent int    `json:"coverage_percent"`
+	Threshold       int    `json:"threshold"`
+	ExpectedLoss    int    `json:"expected_loss"` // Expected covered loss over the policy term
+	Premium         int    `json:"premium"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+// Items a player can hold in their inventory. Cards give them with "keep:<ITEM>" and they
+// can be traded like cash and properties.
+const (
+	ItemJailFree    = "JAIL_FREE"    // Leave jail without paying bail (USE_ITEM while in jail)
+	ItemMoveAgain   = "MOVE_AGAIN"   // Roll again after moving (USE_ITEM after rolling)
+	ItemShield      = "SHIELD"       // Spent automatically on the next rent owed, which is not paid
+	ItemRentDoubler = "RENT_DOUBLER" // Spent automatically on the next rent collected, which is doubled
+)
+
+// ItemInfo describes an item kind
+type ItemInfo struct {
+	Name   string `json:"name"`
+	Usable bool   `json:"usable"` // Played with USE_ITEM; the others are spent automatically
+	Value  int    `json:"value"`  // Rough worth in cash, used to value trades
+}
+
+// Items is the catalog of item kinds
+var Items = map[string]ItemInfo{
+	ItemJailFree:    {Name: "Sal de la Cárcel", Usable: true, Value: 50},
+	ItemMoveAgain:   {Name: "Tirar de nuevo", Usable: true, Value: 40},
+	ItemShield:      {Name: "Escudo", Value: 75},
+	ItemRentDoubler: {Name: "Renta doble", Value: 75},
+}
+
+// Inventory counts the items a player holds: item kind -> quantity
+type Inventory map[string]int
@@ -0,0 +1,43 @@
+package domain
+
+// Ledger accounts that don't belong to a player
+const (
+	AccountBank        = "BANK"
+	AccountFreeParking = "FREE_PARKING"
+)
+
+// SavingsAccountPrefix prefixes a player's ID to name their deposit account
+const SavingsAccountPrefix = "SAVINGS:"
+
+// Reason codes for transfers between players (bank transfers use the Flow* categories)
+const (
+	ReasonRent              = "RENT"
+	ReasonTrade             = "TRADE"
+	ReasonDividend          = "DIVIDEND"
+	ReasonSavingsDeposit    = "SAVINGS_DEPOSIT"
+	ReasonSavingsWithdrawal = "SAVINGS_WITHDRAWAL"
+	ReasonContract          = "CONTRACT"
+	ReasonIssue             = "ISSUE" // Initial cash handed out by the bank
+)
+
+// MaxLedgerEntries is the number of recent transactions kept in the game state
+const MaxLedgerEntries = 200
+
+// Ledger is the game's double-entry book: every change to a balance is a transaction that
+// debits one account and credits another, so the accounts always add up to zero
+type Ledger struct {
+	Accounts     map[string]int      `json:"accounts"`     // Account -> Balance (the bank's is negative: money it issued)
+	Transactions []LedgerTransaction `json:"transactions"` // Most recent transactions
+	NextID       int                 `json:"next_id"`
+}
+
+// LedgerTransaction moves Amount from the From account to the To account
+type LedgerTransaction struct {
+	ID        int    `json:"id"`
+	Timestamp int64  `json:"timestamp"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Amount    int    `json:"amount"`
+	Reason    string `json:"reason"`
+	Detail    string `json:"detail,omitempty"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+// LegalAction is an action a player can take right now, with the values its payload accepts.
+// Fields that don't apply to the action are left empty.
+type LegalAction struct {
+	Action      string   `json:"action"`
+	Description string   `json:"description"`
+	PropertyIDs []string `json:"property_ids,omitempty"` // Values of "property_id"
+	MinAmount   int      `json:"min_amount,omitempty"`   // Bounds of "amount"
+	MaxAmount   int      `json:"max_amount,omitempty"`
+	Options     []string `json:"options,omitempty"`    // Values of "option" (PAY_INCOME_TAX) or "item" (USE_ITEM)
+	TargetIDs   []string `json:"target_ids,omitempty"` // Players a trade can be offered to, as "target_id"
+	TradeIDs    []string `json:"trade_ids,omitempty"`  // Offers waiting for the player's answer, as "trade_id"
+}
@@ -0,0 +1,52 @@
+package domain
+
+// Stock market rules
+const (
+	SharesPerGroup     = 20 // Shares issued per color group
+	DividendPercent    = 10 // % of each rent paid on a group that goes to shareholders
+	MaxPriceHistoryLen = 50 // Price points kept in memory per group (full history is persisted)
+)
+
+// Order sides
+const (
+	OrderSideBuy  = "BUY"
+	OrderSideSell = "SELL"
+)
+
+// StockMarket tracks the shares issued by every color group in a game
+type StockMarket struct {
+	Stocks      map[string]*GroupStock `json:"stocks"` // GroupIdentifier -> Stock
+	Orders      []*ShareOrder          `json:"orders"` // Open limit orders (order book)
+	NextOrderID int                    `json:"next_order_id"`
+}
+
+// GroupStock is the share registry and quote of a single color group
+type GroupStock struct {
+	GroupID     string            `json:"group_id"`
+	GroupName   string            `json:"group_name"`
+	GroupColor  string            `json:"group_color,omitempty"`
+	TotalShares int               `json:"total_shares"`
+	BankShares  int               `json:"bank_shares"` // Shares still held by the bank
+	Holdings    map[string]int    `json:"holdings"`    // UserID -> Shares held
+	Price       int               `json:"price"`       // Current price per share
+	RentIncome  int               `json:"rent_income"` // Cumulative rent collected on the group
+	History     []SharePricePoint `json:"history"`
+}
+
+// SharePricePoint is a single entry of a group's price history
+type SharePricePoint struct {
+	Price     int   `json:"price"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+// ShareOrder is a resting limit order in the order book
+type ShareOrder struct {
+	ID         string `json:"id"`
+	GroupID    string `json:"group_id"`
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Side       string `json:"side"` // BUY, SELL
+	Quantity   int    `json:"quantity"`
+	LimitPrice int    `json:"limit_price"`
+	Timestamp  int64  `json:"timestamp"`
+}
@@ -2,6 +2,7 @@ package domain
 
 type Property struct {
 	ID              string `json:"id"`
+	Slug            string `json:"slug"`
 	Name            string `json:"name"`
 	Type            string `json:"type"`
 	GroupID         string `json:"group_id"`    // e.g. "1.1", "1.2"
@@ -0,0 +1,17 @@
+package domain
+
+// Savings account rules
+const (
+	SavingsRate               = 3  // % interest per round when no central bank is active
+	SavingsLockRounds         = 2  // Rounds a deposit must stay before penalty-free withdrawal
+	EarlyWithdrawalPenaltyPct = 10 // % of the withdrawn amount kept by the bank when locked
+)
+
+// Standing is a player's final position ranked by net worth
+type Standing struct {
+	Rank     int    `json:"rank"`
+	UserID   string `json:"user_id"`
+	Name     string `json:"name"`
+	NetWorth int    `json:"net_worth"`
+	Bankrupt bool   `json:"bankrupt"`
+}
@@ -0,0 +1,13 @@
+package domain
+
+// GameSettings holds the optional house rules chosen by the host when starting the game
+type GameSettings struct {
+	StockMarket        bool              `json:"stock_market"`            // Color groups issue tradable shares
+	CentralBank        bool              `json:"central_bank"`            // Dynamic base rate and inflation
+	Taxes              *TaxRules         `json:"taxes,omitempty"`         // nil = DefaultTaxRules
+	FreeParkingJackpot bool              `json:"free_parking_jackpot"`    // Fines go to the Free Parking pot instead of the bank
+	AuctionFormat      string            `json:"auction_format"`          // Default auction format ("" = ENGLISH)
+	BankAuctions       *BankAuctionRules `json:"bank_auctions,omitempty"` // nil = the bank never auctions
+	CardDeckID         int               `json:"card_deck_id,omitempty"`  // 0 = the default deck
+	BoardID            int               `json:"board_id,omitempty"`      // 0 = the classic board
+}
@@ -0,0 +1,61 @@
+package domain
+
+// Tax types recorded in the ledger
+const (
+	TaxIncome       = "INCOME"
+	TaxLuxury       = "LUXURY"
+	TaxProperty     = "PROPERTY"
+	TaxCapitalGains = "CAPITAL_GAINS"
+)
+
+// Tax destinations
+const (
+	TaxToBank        = "BANK"
+	TaxToFreeParking = "FREE_PARKING"
+)
+
+// Income tax options offered on the income tax tile
+const (
+	IncomeTaxFlat    = "FLAT"
+	IncomeTaxPercent = "PERCENT"
+)
+
+// MaxTaxLedgerLen is the number of ledger entries kept per player
+const MaxTaxLedgerLen = 50
+
+// TaxRules configures how taxes are charged in a game
+type TaxRules struct {
+	IncomeTaxFlat       int    `json:"income_tax_flat"`        // Flat option on the income tax tile
+	IncomeTaxPercent    int    `json:"income_tax_percent"`     // % of net worth option (0 = flat only)
+	LuxuryTax           int    `json:"luxury_tax"`             // Charged on the luxury tax tile
+	PropertyTaxPerHouse int    `json:"property_tax_per_house"` // Per round (0 = disabled)
+	PropertyTaxPerHotel int    `json:"property_tax_per_hotel"` // Per round (0 = disabled)
+	CapitalGainsPercent int    `json:"capital_gains_percent"`  // % of trade/sale profits (0 = disabled)
+	Destination         string `json:"destination"`            // BANK, FREE_PARKING
+}
+
+// DefaultTaxRules reproduces the classic fixed taxes: $200 of income tax with no percentage
+// option, and $100 of luxury tax
+func DefaultTaxRules() TaxRules {
+	return TaxRules{
+		IncomeTaxFlat: 200,
+		LuxuryTax:     100,
+		Destination:   TaxToBank,
+	}
+}
+
+// TaxEntry is a line of a player's tax ledger
+type TaxEntry struct {
+	Type      string `json:"type"`
+	Amount    int    `json:"amount"`
+	Detail    string `json:"detail,omitempty"`
+	Round     int    `json:"round"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// PendingTax is an income tax waiting for the player to choose between flat and percentage
+type PendingTax struct {
+	PlayerID      string `json:"player_id"`
+	FlatAmount    int    `json:"flat_amount"`
+	PercentAmount int    `json:"percent_amount"`
+}
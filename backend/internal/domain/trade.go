@@ -0,0 +1,54 @@
+package domain
+
+// Trade offer statuses
+const (
+	TradePending   = "PENDING"
+	TradeAccepted  = "ACCEPTED"
+	TradeRejected  = "REJECTED"
+	TradeCountered = "COUNTERED" // Replaced by a counter-offer
+	TradeExpired   = "EXPIRED"
+	TradeFailed    = "FAILED" // Accepted but no longer executable
+)
+
+const (
+	TradeExpirySeconds     = 120 // Open offers expire after two minutes
+	MaxOpenTradesPerPlayer = 3   // Open offers a player can have sent at once
+	MaxTradeHistoryLen     = 50
+	MortgageTransferFeePct = 10 // Paid to the bank by whoever receives a mortgaged property
+)
+
+type TradeOffer struct {
+	ID                string   `json:"id"`
+	NegotiationID     string   `json:"negotiation_id"`      // Shared by an offer and all its counter-offers
+	ParentID          string   `json:"parent_id,omitempty"` // Offer this one counters
+	Revision          int      `json:"revision"`            // 1 for the opening offer
+	OffererID         string   `json:"offerer_id"`
+	OffererName       string   `json:"offerer_name"`
+	TargetID          string   `json:"target_id"`
+	TargetName        string   `json:"target_name"`
+	OfferPropeties    []string `json:"offer_properties"`
+	OfferCash         int      `json:"offer_cash"`
+	RequestProperties []string `json:"request_properties"`
+	RequestCash       int      `json:"request_cash"`
+	// Inventory items given by each side of a two-party offer
+	OfferItems   Inventory `json:"offer_items,omitempty"`
+	RequestItems Inventory `json:"request_items,omitempty"`
+	// Contract clauses that come into force when the trade executes
+	Terms     []ContractTerm `json:"terms,omitempty"`
+	Status    string         `json:"status"` // PENDING, ACCEPTED, REJECTED, COUNTERED, EXPIRED, FAILED
+	CreatedAt int64          `json:"created_at"`
+	ExpiresAt int64          `json:"expires_at"`
+	// Multi-party trades list their movements as legs instead of offer/request fields and
+	// run once every participant approved
+	Legs      []TradeLeg      `json:"legs,omitempty"`
+	Approvals map[string]bool `json:"approvals,omitempty"` // UserID -> Approved
+}
+
+// TradeLeg moves cash, properties and items from one participant to another
+type TradeLeg struct {
+	FromID     string    `json:"from_id"`
+	ToID       string    `json:"to_id"`
+	Properties []string  `json:"properties,omitempty"`
+	Cash       int       `json:"cash,omitempty"`
+	Items      Inventory `json:"items,omitempty"`
+}
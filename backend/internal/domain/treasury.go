@@ -0,0 +1,60 @@
+package domain
+
+// Treasury flow categories
+const (
+	FlowTax             = "TAX"
+	FlowBail            = "BAIL"
+	FlowFine            = "FINE"
+	FlowBuilding        = "BUILDING"
+	FlowProperty        = "PROPERTY"
+	FlowAuction         = "AUCTION"
+	FlowMortgage        = "MORTGAGE"
+	FlowInsurance       = "INSURANCE"
+	FlowShares          = "SHARES"
+	FlowLoan            = "LOAN"
+	FlowLoanRepayment   = "LOAN_REPAYMENT"
+	FlowInterest        = "INTEREST"
+	FlowSavingsInterest = "SAVINGS_INTEREST"
+	FlowSalary          = "SALARY"
+	FlowCard            = "CARD"
+	FlowBankruptcy      = "BANKRUPTCY"
+	FlowJackpot         = "JACKPOT" // Free Parking pot paid out
+)
+
+// Flow directions, seen from the bank
+const (
+	FlowIn  = "IN"
+	FlowOut = "OUT"
+)
+
+// MaxTreasuryEntries is the number of recent movements kept in the game state
+const MaxTreasuryEntries = 100
+
+// Treasury tracks every movement of money between the bank and the players
+type Treasury struct {
+	InitialMoney int             `json:"initial_money"` // Cash handed to players at game start
+	Inflows      map[string]int  `json:"inflows"`       // Category -> Total received by the bank
+	Outflows     map[string]int  `json:"outflows"`      // Category -> Total paid by the bank
+	Entries      []TreasuryEntry `json:"entries"`       // Most recent movements
+}
+
+// TreasuryEntry is a single movement between the bank and a player
+type TreasuryEntry struct {
+	Timestamp int64  `json:"timestamp"`
+	Direction string `json:"direction"` // IN, OUT
+	Category  string `json:"category"`
+	Amount    int    `json:"amount"`
+	PlayerID  string `json:"player_id"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// TreasuryReport reconciles the treasury against the money held by players
+type TreasuryReport struct {
+	Treasury       *Treasury `json:"treasury"`
+	TotalInflows   int       `json:"total_inflows"`
+	TotalOutflows  int       `json:"total_outflows"`
+	MoneyInPlay    int       `json:"money_in_play"`    // Player balances + savings
+	ExpectedMoney  int       `json:"expected_money"`   // Initial money + outflows - inflows
+	Discrepancy    int       `json:"discrepancy"`      // MoneyInPlay - ExpectedMoney (0 when balanced)
+	FreeParkingPot int       `json:"free_parking_pot"` // Part of the inflows the bank holds for the jackpot
+}
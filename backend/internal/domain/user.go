@@ -10,6 +10,7 @@ type User struct {
 	SpecialCode *string   `json:"-"` // Pointer to allow nulls if needed, though schema enforces FK
 	TokenColor  string    `json:"token_color"`
 	TokenShape  string    `json:"token_shape"`
+	IsAdmin     bool      `json:"is_admin"`
 	CreatedAt   time.Time `json:"created_at"`
 }
 
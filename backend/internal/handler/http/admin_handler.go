@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/boards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+	"github.com/gabriel3312cl/finances-game/backend/internal/repository/postgres"
+	"github.com/gabriel3312cl/finances-game/backend/internal/service"
+)
+
+// AdminHandler serves the card deck and board editors. Every route is behind AdminMiddleware.
+type AdminHandler struct {
+	gameService *service.GameService
+}
+
+func NewAdminHandler(gameService *service.GameService) *AdminHandler {
+	return &AdminHandler{
+		gameService: gameService,
+	}
+}
+
+// Decks handles GET (list) and POST (create) /api/admin/decks, and GET /api/admin/decks/{id}/cards
+func (h *AdminHandler) Decks(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/cards") {
+		if r.Method != "GET" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		deckID, ok := idFromPath(r.URL.Path, "decks")
+		if !ok {
+			http.Error(w, "Invalid deck ID", http.StatusBadRequest)
+			return
+		}
+		cards, err := h.gameService.ListDeckCards(deckID)
+		writeAdminResult(w, cards, err)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		decks, err := h.gameService.ListCardDecks()
+		writeAdminResult(w, decks, err)
+	case "POST":
+		var deck domain.CardDeck
+		if err := json.NewDecoder(r.Body).Decode(&deck); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		created, err := h.gameService.CreateCardDeck(deck)
+		writeAdminResult(w, created, err)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Cards handles POST /api/admin/cards, POST /api/admin/cards/preview, and PUT (edit) or
+// DELETE (disable) /api/admin/cards/{id}
+func (h *AdminHandler) Cards(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/preview") {
+		if r.Method != "POST" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var card domain.Card
+		if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		writeAdminResult(w, h.gameService.PreviewCard(card), nil)
+		return
+	}
+
+	if r.Method == "POST" {
+		var card domain.Card
+		if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		created, err := h.gameService.CreateCard(card)
+		writeAdminResult(w, created, err)
+		return
+	}
+
+	cardID, ok := idFromPath(r.URL.Path, "cards")
+	if !ok {
+		http.Error(w, "Invalid card ID", http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case "PUT":
+		patch, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		updated, err := h.gameService.UpdateCard(cardID, patch)
+		writeAdminResult(w, updated, err)
+	case "DELETE":
+		disabled, err := h.gameService.UpdateCard(cardID, json.RawMessage(`{"enabled": false}`))
+		writeAdminResult(w, disabled, err)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Boards handles GET (list) and POST (import) /api/admin/boards, and GET (export), PUT (replace)
+// and DELETE /api/admin/boards/{id}. The classic board is ID 0. Boards are validated with
+// POST /api/admin/boards/validate, or GET /api/admin/boards/{id}/validate once stored.
+func (h *AdminHandler) Boards(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/validate") {
+		h.validateBoard(w, r)
+		return
+	}
+	if strings.TrimSuffix(r.URL.Path, "/") == "/api/admin/boards" {
+		switch r.Method {
+		case "GET":
+			writeAdminResult(w, h.gameService.ListBoards(), nil)
+		case "POST":
+			var def domain.BoardDefinition
+			if err := json.NewDecoder(r.Body).Decode(&def); err != nil {
+				http.Error(w, "Invalid request body", http.StatusBadRequest)
+				return
+			}
+			created, err := h.gameService.CreateBoard(def)
+			writeAdminResult(w, created, err)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	boardID, ok := idFromPath(r.URL.Path, "boards")
+	if !ok {
+		http.Error(w, "Invalid board ID", http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		def, err := h.gameService.GetBoard(boardID)
+		writeAdminResult(w, def, err)
+	case "PUT":
+		var def domain.BoardDefinition
+		if err := json.NewDecoder(r.Body).Decode(&def); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		updated, err := h.gameService.UpdateBoard(boardID, def)
+		writeAdminResult(w, updated, err)
+	case "DELETE":
+		err := h.gameService.DeleteBoard(boardID)
+		writeAdminResult(w, map[string]string{"message": "Board deleted"}, err)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *AdminHandler) validateBoard(w http.ResponseWriter, r *http.Request) {
+	var def domain.BoardDefinition
+	switch r.Method {
+	case "POST":
+		if err := json.NewDecoder(r.Body).Decode(&def); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	case "GET":
+		boardID, ok := idFromPath(r.URL.Path, "boards")
+		if !ok {
+			http.Error(w, "Invalid board ID", http.StatusBadRequest)
+			return
+		}
+		stored, err := h.gameService.GetBoard(boardID)
+		if err != nil {
+			writeAdminResult(w, nil, err)
+			return
+		}
+		def = *stored
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeAdminResult(w, h.gameService.ValidateBoard(def), nil)
+}
+
+// idFromPath extracts the numeric ID following the given segment, e.g. /api/admin/cards/{id}
+func idFromPath(path, segment string) (int, bool) {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if part == segment && i+1 < len(parts) {
+			id, err := strconv.Atoi(parts[i+1])
+			return id, err == nil && id >= 0
+		}
+	}
+	return 0, false
+}
+
+// writeAdminResult encodes the result, mapping validation errors to 400 and unknown IDs to 404.
+// A rejected board answers with the report of all its problems.
+func writeAdminResult(w http.ResponseWriter, result interface{}, err error) {
+	var invalidBoard *service.InvalidBoardError
+	if errors.As(err, &invalidBoard) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(boards.Report{Problems: invalidBoard.Problems})
+		return
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCard), errors.Is(err, service.ErrInvalidBoard):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, postgres.ErrCardNotFound), errors.Is(err, postgres.ErrBoardNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/service"
+)
+
+// Requests the handler rejects before the card repository is reached
+func TestAdminHandler_RejectsBadRequests(t *testing.T) {
+	h := NewAdminHandler(&service.GameService{})
+	for _, tc := range []struct {
+		route  http.HandlerFunc
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{h.Decks, "POST", "/api/admin/decks", `{"name": "  "}`, http.StatusBadRequest},
+		{h.Decks, "POST", "/api/admin/decks", `{`, http.StatusBadRequest},
+		{h.Decks, "DELETE", "/api/admin/decks", ``, http.StatusMethodNotAllowed},
+		{h.Decks, "POST", "/api/admin/decks/1/cards", ``, http.StatusMethodNotAllowed},
+		{h.Cards, "PUT", "/api/admin/cards/abc", `{"enabled": false}`, http.StatusBadRequest},
+		{h.Cards, "DELETE", "/api/admin/cards/", ``, http.StatusBadRequest},
+		{h.Cards, "PATCH", "/api/admin/cards/3", ``, http.StatusMethodNotAllowed},
+	} {
+		rec := httptest.NewRecorder()
+		tc.route(rec, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+		if rec.Code != tc.status {
+			t.Errorf("%s %s %s: status %d, want %d", tc.method, tc.path, tc.body, rec.Code, tc.status)
+		}
+	}
+}
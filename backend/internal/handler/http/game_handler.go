@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
 	"github.com/gabriel3312cl/finances-game/backend/internal/service"
@@ -140,3 +141,218 @@ func (h *GameHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(board)
 }
+
+// GetCardDecks lists the card decks a host can pick for their game
+func (h *GameHandler) GetCardDecks(w http.ResponseWriter, r *http.Request) {
+	decks, err := h.gameService.ListCardDecks()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(decks)
+}
+
+// GetBoards lists the boards a host can pick for their game
+func (h *GameHandler) GetBoards(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.gameService.ListBoards())
+}
+
+// gameIDFromPath extracts the game ID from URLs shaped like /api/games/{id}/...
+func gameIDFromPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if part == "games" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// QuoteInsurance handles POST /api/games/{id}/insurance/quote
+func (h *GameHandler) QuoteInsurance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	gameID := gameIDFromPath(r.URL.Path)
+	if gameID == "" {
+		http.Error(w, "Game ID not found in URL", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized: No UserID", http.StatusUnauthorized)
+		return
+	}
+
+	var req domain.InsuranceQuote
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	quote, err := h.gameService.QuoteInsurance(gameID, userID, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(quote)
+}
+
+// GetTreasury handles GET /api/games/{id}/treasury
+func (h *GameHandler) GetTreasury(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	gameID := gameIDFromPath(r.URL.Path)
+	if gameID == "" {
+		http.Error(w, "Game ID not found in URL", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized: No UserID", http.StatusUnauthorized)
+		return
+	}
+
+	report, err := h.gameService.GetTreasuryReport(gameID, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// GetLedger handles GET /api/games/{id}/ledger
+func (h *GameHandler) GetLedger(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	gameID := gameIDFromPath(r.URL.Path)
+	if gameID == "" {
+		http.Error(w, "Game ID not found in URL", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized: No UserID", http.StatusUnauthorized)
+		return
+	}
+
+	ledger, err := h.gameService.GetLedger(gameID, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ledger)
+}
+
+// GetLegalActions handles GET /api/games/{id}/actions: what the player can do right now
+func (h *GameHandler) GetLegalActions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	gameID := gameIDFromPath(r.URL.Path)
+	if gameID == "" {
+		http.Error(w, "Game ID not found in URL", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized: No UserID", http.StatusUnauthorized)
+		return
+	}
+
+	actions, err := h.gameService.GetLegalActions(gameID, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(actions)
+}
+
+// EvaluateTrade handles POST /api/games/{id}/trade/evaluate (EVALUATE_TRADE). The body is
+// {"trade_id"} for an open offer or INITIATE_TRADE terms for a hypothetical one.
+func (h *GameHandler) EvaluateTrade(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	gameID := gameIDFromPath(r.URL.Path)
+	if gameID == "" {
+		http.Error(w, "Game ID not found in URL", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized: No UserID", http.StatusUnauthorized)
+		return
+	}
+
+	var payload json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	score, err := h.gameService.EvaluateTrade(gameID, userID, payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(score)
+}
+
+// GetAuctions handles GET /api/games/{id}/auctions
+func (h *GameHandler) GetAuctions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	gameID := gameIDFromPath(r.URL.Path)
+	if gameID == "" {
+		http.Error(w, "Game ID not found in URL", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized: No UserID", http.StatusUnauthorized)
+		return
+	}
+
+	auctions, err := h.gameService.GetAuctions(gameID, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(auctions)
+}
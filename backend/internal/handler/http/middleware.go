@@ -45,7 +45,7 @@ func AuthMiddleware(repo *postgres.UserRepository, next http.HandlerFunc) http.H
 
 		if claims, ok := token.Claims.(*domain.AuthClaims); ok && token.Valid {
 			// CRITICAL: Check if user still exists in DB (handles stale tokens after DB reset)
-			_, err := repo.GetByID(claims.UserID)
+			user, err := repo.GetByID(claims.UserID)
 			if err != nil {
 				log.Printf("Auth failed: User %s (%s) not found in DB. Stale token suspected.", claims.Username, claims.UserID)
 				http.Error(w, "User no longer exists. Please log in again.", http.StatusUnauthorized)
@@ -54,6 +54,7 @@ func AuthMiddleware(repo *postgres.UserRepository, next http.HandlerFunc) http.H
 
 			ctx := context.WithValue(r.Context(), "user_id", claims.UserID)
 			ctx = context.WithValue(ctx, "username", claims.Username)
+			ctx = context.WithValue(ctx, "is_admin", user.IsAdmin)
 			next(w, r.WithContext(ctx))
 		} else {
 			log.Println("Auth failed: Invalid Claims")
@@ -62,6 +63,22 @@ func AuthMiddleware(repo *postgres.UserRepository, next http.HandlerFunc) http.H
 	}
 }
 
+// AdminMiddleware validates the JWT like AuthMiddleware and only lets admins through
+func AdminMiddleware(repo *postgres.UserRepository, next http.HandlerFunc) http.HandlerFunc {
+	return AuthMiddleware(repo, requireAdmin(next))
+}
+
+// requireAdmin rejects callers that AuthMiddleware didn't mark as admins
+func requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if isAdmin, _ := r.Context().Value("is_admin").(bool); !isAdmin {
+			http.Error(w, "Admin access required", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
 // LoggingMiddleware logs incoming requests
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
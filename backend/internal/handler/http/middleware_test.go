@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminMiddleware_RejectsNonAdmins(t *testing.T) {
+	reached := false
+	next := func(w http.ResponseWriter, r *http.Request) { reached = true }
+
+	// No token never reaches the user lookup
+	rec := httptest.NewRecorder()
+	AdminMiddleware(nil, next)(rec, httptest.NewRequest("GET", "/api/admin/decks", nil))
+	if rec.Code != http.StatusUnauthorized || reached {
+		t.Errorf("anonymous caller: status %d, reached %v", rec.Code, reached)
+	}
+
+	for _, tc := range []struct {
+		isAdmin any
+		status  int
+	}{
+		{false, http.StatusForbidden},
+		{nil, http.StatusForbidden},
+		{true, http.StatusOK},
+	} {
+		reached = false
+		req := httptest.NewRequest("POST", "/api/admin/cards", nil)
+		if tc.isAdmin != nil {
+			req = req.WithContext(context.WithValue(req.Context(), "is_admin", tc.isAdmin))
+		}
+		rec := httptest.NewRecorder()
+		requireAdmin(next)(rec, req)
+		if rec.Code != tc.status || reached != (tc.status == http.StatusOK) {
+			t.Errorf("is_admin %v: status %d, reached %v", tc.isAdmin, rec.Code, reached)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package websocket
 
 type BroadcastMessage struct {
 	GameID  string
+	UserID  string // Only this player's clients get the message when set
 	Payload []byte
 	Sender  *Client
 }
@@ -51,6 +52,9 @@ func (h *Hub) Run() {
 			// Broadcast only to clients in the same GameID
 			if clients, ok := h.Clients[message.GameID]; ok {
 				for client := range clients {
+					if message.UserID != "" && client.UserID != message.UserID {
+						continue
+					}
 					select {
 					case client.Send <- message.Payload:
 					default:
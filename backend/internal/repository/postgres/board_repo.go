@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// ErrBoardNotFound is returned when a board ID doesn't exist
+var ErrBoardNotFound = errors.New("board not found")
+
+// ListBoards returns every custom board definition, oldest first
+func (r *GameRepository) ListBoards() ([]domain.BoardDefinition, error) {
+	rows, err := r.db.Query(`SELECT id, name, COALESCE(description, ''), definition FROM board_definitions ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	boards := []domain.BoardDefinition{}
+	for rows.Next() {
+		var id int
+		var name, description string
+		var definition []byte
+		if err := rows.Scan(&id, &name, &description, &definition); err != nil {
+			return nil, err
+		}
+		var b domain.BoardDefinition
+		if err := json.Unmarshal(definition, &b); err != nil {
+			return nil, err
+		}
+		b.ID, b.Name, b.Description = id, name, description
+		boards = append(boards, b)
+	}
+	return boards, rows.Err()
+}
+
+// CreateBoard inserts a board definition and sets its ID
+func (r *GameRepository) CreateBoard(b *domain.BoardDefinition) error {
+	definition, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO board_definitions (name, description, definition) VALUES ($1, $2, $3) RETURNING id`
+	return r.db.QueryRow(query, b.Name, b.Description, definition).Scan(&b.ID)
+}
+
+// UpdateBoard replaces a board definition
+func (r *GameRepository) UpdateBoard(b *domain.BoardDefinition) error {
+	definition, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE board_definitions SET name = $2, description = $3, definition = $4, updated_at = NOW() WHERE id = $1`
+	res, err := r.db.Exec(query, b.ID, b.Name, b.Description, definition)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrBoardNotFound
+	}
+	return nil
+}
+
+// DeleteBoard removes a board definition
+func (r *GameRepository) DeleteBoard(id int) error {
+	res, err := r.db.Exec(`DELETE FROM board_definitions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrBoardNotFound
+	}
+	return nil
+}
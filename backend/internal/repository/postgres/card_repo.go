@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// ErrCardNotFound is returned when a card or deck ID doesn't exist
+var ErrCardNotFound = errors.New("card not found")
+
+const cardColumns = `id, COALESCE(deck_id, 0), type, COALESCE(title, ''), description, effect, COALESCE(enabled, TRUE)`
+
+func scanCard(row interface{ Scan(...any) error }) (domain.Card, error) {
+	var c domain.Card
+	err := row.Scan(&c.ID, &c.DeckID, &c.Type, &c.Title, &c.Description, &c.Effect, &c.Enabled)
+	return c, err
+}
+
+// ListCards returns the cards of every deck, or of a single one when deckID is not 0
+func (r *GameRepository) ListCards(deckID int, includeDisabled bool) ([]domain.Card, error) {
+	query := `SELECT ` + cardColumns + ` FROM game_cards
+	WHERE ($1 = 0 OR deck_id = $1) AND ($2 OR COALESCE(enabled, TRUE))
+	ORDER BY deck_id, type, id`
+	rows, err := r.db.Query(query, deckID, includeDisabled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cards := []domain.Card{}
+	for rows.Next() {
+		c, err := scanCard(rows)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, rows.Err()
+}
+
+// GetCard loads a single card
+func (r *GameRepository) GetCard(id int) (*domain.Card, error) {
+	c, err := scanCard(r.db.QueryRow(`SELECT `+cardColumns+` FROM game_cards WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrCardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// CreateCard inserts a card and sets its ID
+func (r *GameRepository) CreateCard(c *domain.Card) error {
+	query := `
+	INSERT INTO game_cards (deck_id, type, title, description, effect, enabled)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id`
+	return r.db.QueryRow(query, c.DeckID, c.Type, c.Title, c.Description, c.Effect, c.Enabled).Scan(&c.ID)
+}
+
+// UpdateCard overwrites every field of a card
+func (r *GameRepository) UpdateCard(c *domain.Card) error {
+	query := `
+	UPDATE game_cards SET deck_id = $2, type = $3, title = $4, description = $5, effect = $6, enabled = $7
+	WHERE id = $1`
+	res, err := r.db.Exec(query, c.ID, c.DeckID, c.Type, c.Title, c.Description, c.Effect, c.Enabled)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrCardNotFound
+	}
+	return nil
+}
+
+// ListCardDecks returns every deck with its number of enabled cards, oldest first
+func (r *GameRepository) ListCardDecks() ([]domain.CardDeck, error) {
+	query := `
+	SELECT d.id, d.name, COALESCE(d.description, ''),
+		COUNT(c.id) FILTER (WHERE COALESCE(c.enabled, TRUE))
+	FROM card_decks d
+	LEFT JOIN game_cards c ON c.deck_id = d.id
+	GROUP BY d.id
+	ORDER BY d.id`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	decks := []domain.CardDeck{}
+	for rows.Next() {
+		var d domain.CardDeck
+		if err := rows.Scan(&d.ID, &d.Name, &d.Description, &d.CardCount); err != nil {
+			return nil, err
+		}
+		decks = append(decks, d)
+	}
+	return decks, rows.Err()
+}
+
+// CreateCardDeck inserts a deck and sets its ID
+func (r *GameRepository) CreateCardDeck(d *domain.CardDeck) error {
+	return r.db.QueryRow(`INSERT INTO card_decks (name, description) VALUES ($1, $2) RETURNING id`, d.Name, d.Description).Scan(&d.ID)
+}
@@ -49,18 +49,23 @@ func (r *GameRepository) Save(game *domain.GameState) error {
 			continue
 		}
 
-		// Convert inventory to JSON (if used for cards)
-		// For now empty or whatever is in struct. But struct Inventory field?
-		// PlayerState doesn't explicit have Inventory field in the viewed struct (it has [], but check definitions).
-		// Assuming we just update balance/position/status.
+		// Inventory items as a JSON object (item kind -> quantity)
+		inventory := p.Inventory
+		if inventory == nil {
+			inventory = domain.Inventory{}
+		}
+		inventoryJSON, err := json.Marshal(inventory)
+		if err != nil {
+			return err
+		}
 
 		pq := `
-		INSERT INTO game_players (game_id, user_id, token_color, balance, position, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO game_players (game_id, user_id, token_color, balance, position, is_active, inventory)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		ON CONFLICT (game_id, user_id) DO UPDATE
-		SET balance = $4, position = $5, is_active = $6, token_color = $3;
+		SET balance = $4, position = $5, is_active = $6, token_color = $3, inventory = $7;
 		`
-		if _, err := tx.Exec(pq, game.GameID, p.UserID, p.TokenColor, p.Balance, p.Position, p.IsActive); err != nil {
+		if _, err := tx.Exec(pq, game.GameID, p.UserID, p.TokenColor, p.Balance, p.Position, p.IsActive, inventoryJSON); err != nil {
 			log.Printf("Error syncing player %s: %v", p.Name, err)
 			// Don't fail entire save for one player error? Or should we?
 			// Let's return error to be safe.
@@ -200,6 +205,66 @@ func (r *GameRepository) LoadBoardLayout() (map[int]struct {
 	return layout, nil
 }
 
+// SaveSharePrice appends a point to a group's share price history
+func (r *GameRepository) SaveSharePrice(gameID string, groupID string, price int) error {
+	query := `INSERT INTO share_price_history (game_id, group_id, price, created_at) VALUES ($1, $2, $3, $4)`
+	_, err := r.db.Exec(query, gameID, groupID, price, time.Now())
+	return err
+}
+
+// SaveAuction stores the outcome of a finished auction with its bid history
+func (r *GameRepository) SaveAuction(gameID string, record domain.AuctionRecord) error {
+	bidsJSON, err := json.Marshal(record.Bids)
+	if err != nil {
+		return err
+	}
+	query := `
+	INSERT INTO auctions (game_id, auction_id, property_id, property_name, format, opening_bid, winner_id, winner_name, price, status, bids, started_at, ended_at)
+	VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9, $10, $11, $12, $13)
+	ON CONFLICT (game_id, auction_id) DO NOTHING;
+	`
+	_, err = r.db.Exec(query, gameID, record.AuctionID, record.PropertyID, record.PropertyName, record.Format, record.OpeningBid,
+		record.WinnerID, record.WinnerName, record.Price, record.Status, bidsJSON, record.StartedAt, record.EndedAt)
+	return err
+}
+
+// ListAuctions returns the finished auctions of a game, oldest first
+func (r *GameRepository) ListAuctions(gameID string) ([]domain.AuctionRecord, error) {
+	query := `
+	SELECT auction_id, property_id, property_name, format, opening_bid,
+		COALESCE(winner_id, ''), COALESCE(winner_name, ''), price, status, bids, started_at, ended_at
+	FROM auctions
+	WHERE game_id = $1
+	ORDER BY ended_at`
+	rows, err := r.db.Query(query, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []domain.AuctionRecord{}
+	for rows.Next() {
+		var rec domain.AuctionRecord
+		var bidsJSON []byte
+		if err := rows.Scan(&rec.AuctionID, &rec.PropertyID, &rec.PropertyName, &rec.Format, &rec.OpeningBid,
+			&rec.WinnerID, &rec.WinnerName, &rec.Price, &rec.Status, &bidsJSON, &rec.StartedAt, &rec.EndedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bidsJSON, &rec.Bids); err != nil {
+			log.Printf("Error decoding bids of auction %s: %v", rec.AuctionID, err)
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// HasPlayer reports whether a user played in a game
+func (r *GameRepository) HasPlayer(gameID string, userID string) (bool, error) {
+	var played bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM game_players WHERE game_id = $1 AND user_id::text = $2)`, gameID, userID).Scan(&played)
+	return played, err
+}
+
 // Delete permanently removes a game and its associated data
 func (r *GameRepository) Delete(gameID string) error {
 	// Cascading delete handled by DB schema
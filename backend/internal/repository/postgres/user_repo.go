@@ -42,8 +42,8 @@ func (r *UserRepository) GetByUsername(username string) (*domain.User, error) {
 
 func (r *UserRepository) GetByID(id string) (*domain.User, error) {
 	u := &domain.User{}
-	query := `SELECT id, username, created_at, COALESCE(token_color, 'RED'), COALESCE(token_shape, 'CUBE') FROM users WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(&u.ID, &u.Username, &u.CreatedAt, &u.TokenColor, &u.TokenShape)
+	query := `SELECT id, username, created_at, COALESCE(token_color, 'RED'), COALESCE(token_shape, 'CUBE'), COALESCE(is_admin, FALSE) FROM users WHERE id = $1`
+	err := r.db.QueryRow(query, id).Scan(&u.ID, &u.Username, &u.CreatedAt, &u.TokenColor, &u.TokenShape, &u.IsAdmin)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("user not found")
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -21,8 +22,10 @@ type AdvisorService struct {
 
 // ChatMessage represents a message in the conversation
 type ChatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role       string        `json:"role"`
+	Content    string        `json:"content"`
+	ToolCalls  []LLMToolCall `json:"tool_calls,omitempty"`   // Functions the assistant called
+	ToolCallID string        `json:"tool_call_id,omitempty"` // Call a "tool" message answers
 }
 
 // ChatRequest is the request to the advisor
@@ -46,6 +49,31 @@ type LLMRequest struct {
 	Temperature float64       `json:"temperature"`
 	MaxTokens   int           `json:"max_tokens"`
 	Stream      bool          `json:"stream"`
+	Tools       []LLMTool     `json:"tools,omitempty"`
+	ToolChoice  string        `json:"tool_choice,omitempty"` // "auto", "required" or "none"
+}
+
+// LLMTool is a function the LLM may call, in the OpenAI format
+type LLMTool struct {
+	Type     string      `json:"type"` // Always "function"
+	Function LLMFunction `json:"function"`
+}
+
+// LLMFunction describes a callable function; Parameters is a JSON schema of its arguments
+type LLMFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters"`
+}
+
+// LLMToolCall is a function call in an assistant message; Arguments is a JSON object
+type LLMToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
 }
 
 // LLMResponse is the response format from LLM Studio
@@ -176,8 +204,7 @@ DIRECTRICES CRÍTICAS:
 		}
 	}
 
-	// Calculate net worth
-	netWorth := player.Balance
+	// Property totals
 	totalPropertyValue := 0
 	totalRentPotential := 0
 
@@ -187,6 +214,9 @@ DIRECTRICES CRÍTICAS:
 	sb.WriteString("╚══════════════════════════════════════╝\n")
 	sb.WriteString(fmt.Sprintf("Nombre: %s\n", player.Name))
 	sb.WriteString(fmt.Sprintf("Balance en efectivo: $%d\n", player.Balance))
+	if player.Savings > 0 {
+		sb.WriteString(fmt.Sprintf("Cuenta de ahorro: $%d\n", player.Savings))
+	}
 	sb.WriteString(fmt.Sprintf("Posición actual: Casilla #%d - %s\n", player.Position, currentTileName))
 
 	// EXPLICIT Jail status
@@ -313,8 +343,6 @@ DIRECTRICES CRÍTICAS:
 		}
 	}
 
-	netWorth += totalPropertyValue
-
 	// Other players summary
 	sb.WriteString("\n╔══════════════════════════════════════╗\n")
 	sb.WriteString("║      OTROS JUGADORES                 ║\n")
@@ -341,6 +369,24 @@ DIRECTRICES CRÍTICAS:
 		}
 	}
 
+	// Open trades involving the player, valued from their point of view
+	if trades := sortedTrades(game, func(t *domain.TradeOffer) bool { return isTradeParticipant(t, userID) }); len(trades) > 0 {
+		sb.WriteString("\n🤝 OFERTAS DE INTERCAMBIO ABIERTAS (valoración para ti):\n")
+		for _, t := range trades {
+			score := scoreTrade(game, t)
+			side := score.Side(userID)
+			if side == nil {
+				continue
+			}
+			fairness := "equilibrada"
+			if !score.Fair {
+				fairness = "desequilibrada"
+			}
+			sb.WriteString(fmt.Sprintf("• %s (%s → %s): recibes ~$%d, entregas ~$%d, neto $%d, oferta %s\n",
+				t.ID, t.OffererName, t.TargetName, side.Receives, side.Gives, side.Net, fairness))
+		}
+	}
+
 	// Recent events (last 5 for clarity)
 	sb.WriteString("\n📜 ÚLTIMOS EVENTOS:\n")
 	logStart := 0
@@ -369,8 +415,19 @@ DIRECTRICES CRÍTICAS:
 		sb.WriteString(fmt.Sprintf("💰 RENTA PENDIENTE DE COBRO: $%d\n", game.PendingRent.Amount))
 	}
 
+	if game.Economy != nil {
+		sb.WriteString(fmt.Sprintf("🏦 BANCO CENTRAL: Tasa base %d%% | Inflación última ronda %.1f%% | Índice de precios %.2f\n",
+			game.Economy.BaseRate, game.Economy.Inflation, game.Economy.PriceIndex))
+	}
+
+	// What the player can do right now, straight from the rules
+	sb.WriteString("\n✅ ACCIONES DISPONIBLES AHORA (recomienda solo estas):\n")
+	for _, a := range s.gameService.LegalActions(game, userID) {
+		sb.WriteString("- " + describeLegalAction(game, a) + "\n")
+	}
+
 	// Net worth summary
-	sb.WriteString(fmt.Sprintf("\n💎 TU PATRIMONIO NETO ESTIMADO: $%d\n", netWorth-player.Loan))
+	sb.WriteString(fmt.Sprintf("\n💎 TU PATRIMONIO NETO ESTIMADO: $%d\n", s.gameService.netWorth(game, player)))
 
 	return sb.String()
 }
@@ -567,3 +624,25 @@ func (s *AdvisorService) GetAdviceStream(req *ChatRequest, chunkChan chan<- stri
 		partialData = lines[len(lines)-1]
 	}
 }
+
+// describeLegalAction writes a legal action for a prompt, with the properties and amounts it
+// accepts
+func describeLegalAction(game *domain.GameState, a domain.LegalAction) string {
+	text := a.Action + ": " + a.Description
+	if len(a.PropertyIDs) > 0 {
+		names := make([]string, 0, len(a.PropertyIDs))
+		for _, t := range game.Board {
+			if slices.Contains(a.PropertyIDs, t.PropertyID) {
+				names = append(names, t.Name)
+			}
+		}
+		text += " [" + strings.Join(names, ", ") + "]"
+	}
+	if a.MaxAmount > 0 {
+		text += fmt.Sprintf(" ($%d a $%d)", a.MinAmount, a.MaxAmount)
+	}
+	if len(a.Options) > 0 {
+		text += " [" + strings.Join(a.Options, ", ") + "]"
+	}
+	return text
+}
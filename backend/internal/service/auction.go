@@ -0,0 +1,526 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// handleStartAuction puts the unowned property the current player landed on up for auction,
+// instead of buying it
+func (s *GameService) handleStartAuction(game *domain.GameState, userID string, payload json.RawMessage) {
+	var req struct {
+		PropertyID string `json:"property_id"`
+		Format     string `json:"format"` // Host only: overrides the game's auction format
+	}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return
+	}
+
+	player := s.getPlayer(game, userID)
+	if player == nil || game.Status != domain.GameStatusActive || game.CurrentTurnID != userID || game.Dice[0] == 0 {
+		return
+	}
+	if game.ActiveAuction != nil && game.ActiveAuction.IsActive {
+		s.addLog(game, "Ya hay una subasta en curso", "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+
+	// Only the tile the player stands on, if nobody owns it
+	var tile *domain.Tile
+	if player.Position >= 0 && player.Position < len(game.Board) {
+		tile = &game.Board[player.Position]
+	}
+	if tile == nil || tile.Price <= 0 || (req.PropertyID != "" && req.PropertyID != tile.PropertyID) {
+		s.addLog(game, "Solo puedes subastar la propiedad en la que caíste", "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+	if _, owned := game.PropertyOwnership[tile.PropertyID]; owned {
+		s.addLog(game, tile.Name+" ya tiene dueño", "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+
+	format := game.Settings.AuctionFormat
+	if req.Format != "" && userID == game.HostID {
+		format = req.Format
+	}
+	opening := tile.Price * domain.AuctionOpeningPct / 100
+	s.startAuction(game, tile, player.Name, format, opening)
+	s.broadcastGameState(game)
+}
+
+// startAuction opens an auction for a property. opening is the minimum first bid (the reserve
+// price); startedBy names who called it in the log.
+func (s *GameService) startAuction(game *domain.GameState, tile *domain.Tile, startedBy string, format string, opening int) {
+	if !domain.ValidAuctionFormat(format) {
+		format = domain.AuctionEnglish
+	}
+	if opening < domain.AuctionMinIncrement {
+		opening = domain.AuctionMinIncrement
+	}
+	now := time.Now()
+
+	game.NextAuctionID++
+	game.ActiveAuction = &domain.AuctionState{
+		ID:            fmt.Sprintf("AU-%d", game.NextAuctionID),
+		PropertyID:    tile.PropertyID,
+		Format:        format,
+		StartedBy:     startedBy,
+		StartTime:     now.Unix(),
+		OpeningBid:    opening,
+		MinIncrement:  domain.AuctionMinIncrement,
+		HighestBid:    opening,
+		BidderID:      "",
+		BidderName:    "No bids",
+		EndTime:       now.Add(domain.AuctionDurationSecs * time.Second),
+		LastBidTime:   now.Unix(),
+		IsActive:      true,
+		PassedPlayers: make(map[string]bool),
+	}
+	switch format {
+	case domain.AuctionSealedFirst, domain.AuctionSealedSecond:
+		game.ActiveAuction.SealedBids = make(map[string]int)
+	case domain.AuctionDutch:
+		// The clock starts at twice the list price and runs down to the opening bid
+		game.ActiveAuction.StartPrice = max(tile.Price*2, opening)
+		game.ActiveAuction.FloorPrice = opening
+		game.ActiveAuction.HighestBid = game.ActiveAuction.StartPrice
+		game.ActiveAuction.EndTime = now.Add((domain.DutchDropSteps + 1) * domain.DutchDropInterval)
+	}
+	game.LastAction = "Subasta de " + tile.Name + " iniciada por " + startedBy
+	s.addLog(game, "🔨 Subasta ("+format+") de "+tile.Name+" iniciada por "+startedBy+". Oferta mínima: $"+strconv.Itoa(opening), "INFO")
+}
+
+// minNextBid is the lowest bid an open auction accepts now
+func minNextBid(auction *domain.AuctionState) int {
+	if auction.BidderID == "" {
+		return auction.HighestBid
+	}
+	return auction.HighestBid + max(auction.MinIncrement, domain.AuctionMinIncrement)
+}
+
+// recordBid appends a bid to the auction history
+func recordBid(auction *domain.AuctionState, player *domain.PlayerState, amount int) {
+	auction.Bids = append(auction.Bids, domain.AuctionBid{
+		PlayerID:   player.UserID,
+		PlayerName: player.Name,
+		Amount:     amount,
+		Timestamp:  time.Now().Unix(),
+	})
+	auction.LastBidTime = time.Now().Unix()
+}
+
+func (s *GameService) handleFinalizeAuction(game *domain.GameState) {
+	if game.ActiveAuction == nil || !game.ActiveAuction.IsActive {
+		return
+	}
+	// Check if time is actually up
+	now := time.Now()
+	lastBid := time.Unix(game.ActiveAuction.LastBidTime, 0)
+
+	// Auto-Win: If > 5s passed since last bid and we have a bidder (open auctions only)
+	english := auctionFormat(game.ActiveAuction) == domain.AuctionEnglish
+	if english && game.ActiveAuction.BidderID != "" && now.Sub(lastBid) > 5*time.Second {
+		s.endAuction(game)
+		return
+	}
+
+	if now.After(game.ActiveAuction.EndTime) {
+		s.endAuction(game)
+	}
+}
+
+func (s *GameService) handleBid(game *domain.GameState, userID string, payload json.RawMessage) {
+	if game.ActiveAuction == nil || !game.ActiveAuction.IsActive {
+		return
+	}
+
+	// Check expiry
+	if time.Now().After(game.ActiveAuction.EndTime) {
+		s.endAuction(game)
+		return
+	}
+
+	var req struct {
+		Amount int `json:"amount"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return
+	}
+
+	player := s.getPlayer(game, userID)
+	if player == nil || !player.IsActive || game.ActiveAuction.PassedPlayers[userID] {
+		return
+	}
+
+	switch auctionFormat(game.ActiveAuction) {
+	case domain.AuctionSealedFirst, domain.AuctionSealedSecond:
+		s.handleSealedBid(game, player, req.Amount)
+		return
+	case domain.AuctionDutch:
+		s.handleDutchBid(game, player)
+		return
+	}
+
+	// Validate Bid
+	if req.Amount < minNextBid(game.ActiveAuction) || game.ActiveAuction.BidderID == userID {
+		return
+	}
+	if player.Balance < req.Amount {
+		return // Insufficient funds
+	}
+
+	recordBid(game.ActiveAuction, player, req.Amount)
+	game.ActiveAuction.HighestBid = req.Amount
+	game.ActiveAuction.BidderID = userID
+	game.ActiveAuction.BidderName = player.Name
+
+	// Anti-sniping: extend if < 10s left
+	timeLeft := time.Until(game.ActiveAuction.EndTime)
+	if timeLeft < 10*time.Second {
+		game.ActiveAuction.EndTime = time.Now().Add(10 * time.Second) // Extend time
+	}
+
+	s.addLog(game, player.Name+" ha pujado $"+strconv.Itoa(req.Amount), "INFO")
+	s.broadcastGameState(game)
+}
+
+func (s *GameService) handlePassAuction(game *domain.GameState, userID string) {
+	if game.ActiveAuction == nil || !game.ActiveAuction.IsActive {
+		return
+	}
+	if game.ActiveAuction.PassedPlayers == nil {
+		game.ActiveAuction.PassedPlayers = make(map[string]bool)
+	}
+	game.ActiveAuction.PassedPlayers[userID] = true
+	s.addLog(game, "Jugador ha pasado en la subasta.", "INFO")
+	// Check if only 1 player remaining? Not implementing complex logic yet.
+	if isSealedAuction(game.ActiveAuction) && sealedBidsComplete(game) {
+		s.endAuction(game)
+		return
+	}
+	s.broadcastGameState(game)
+}
+
+// handleSealedBid files (or replaces) a player's hidden bid; the auction closes early once
+// every player has bid or passed
+func (s *GameService) handleSealedBid(game *domain.GameState, player *domain.PlayerState, amount int) {
+	if amount < game.ActiveAuction.OpeningBid || amount > player.Balance {
+		return
+	}
+
+	if _, ok := game.ActiveAuction.SealedBids[player.UserID]; !ok {
+		game.ActiveAuction.Bidders = append(game.ActiveAuction.Bidders, player.UserID)
+	}
+	game.ActiveAuction.SealedBids[player.UserID] = amount
+	recordBid(game.ActiveAuction, player, amount)
+	s.addLog(game, player.Name+" entregó su oferta en sobre cerrado", "INFO")
+
+	if sealedBidsComplete(game) {
+		s.endAuction(game)
+		return
+	}
+	s.broadcastGameState(game)
+}
+
+// handleDutchBid accepts the current asking price of a Dutch auction: the first taker wins
+func (s *GameService) handleDutchBid(game *domain.GameState, player *domain.PlayerState) {
+	price := dutchPrice(game.ActiveAuction, time.Now())
+	if player.Balance < price {
+		return
+	}
+
+	recordBid(game.ActiveAuction, player, price)
+	game.ActiveAuction.HighestBid = price
+	game.ActiveAuction.BidderID = player.UserID
+	game.ActiveAuction.BidderName = player.Name
+	s.addLog(game, player.Name+" aceptó el precio de $"+strconv.Itoa(price), "INFO")
+	s.endAuction(game)
+}
+
+// endAuction closes the auction: the winner pays the bank and gets the property, and the
+// outcome is stored with its bid history
+func (s *GameService) endAuction(game *domain.GameState) {
+	if game.ActiveAuction == nil || !game.ActiveAuction.IsActive {
+		return
+	}
+	auction := game.ActiveAuction
+
+	if isSealedAuction(auction) {
+		s.openSealedBids(game)
+	} else {
+		s.checkOpenAuctionWinner(game)
+	}
+
+	tile := s.propertyTile(game, auction.PropertyID)
+	record := domain.AuctionRecord{
+		AuctionID:  auction.ID,
+		PropertyID: auction.PropertyID,
+		Format:     auctionFormat(auction),
+		OpeningBid: auction.OpeningBid,
+		Status:     domain.AuctionUnsold,
+		Bids:       auction.Bids,
+		StartedAt:  time.Unix(auction.StartTime, 0),
+		EndedAt:    time.Now(),
+	}
+	if tile != nil {
+		record.PropertyName = tile.Name
+	}
+
+	winner := s.getPlayer(game, auction.BidderID)
+	if winner != nil && tile != nil {
+		amount := auction.HighestBid
+		s.bankCollect(game, winner, amount, domain.FlowAuction, auction.PropertyID)
+		game.PropertyOwnership[auction.PropertyID] = winner.UserID
+		setCostBasis(game, auction.PropertyID, amount)
+		tile.OwnerID = &winner.UserID
+
+		record.Status = domain.AuctionSold
+		record.WinnerID = winner.UserID
+		record.WinnerName = winner.Name
+		record.Price = amount
+
+		game.LastAction = "¡Subasta finalizada! Ganador: " + winner.Name
+		s.addLog(game, "¡Subasta finalizada! Ganador: "+winner.Name+" por $"+strconv.Itoa(amount), "SUCCESS")
+	} else {
+		game.LastAction = "¡Subasta finalizada! Sin ofertas."
+	}
+
+	if s.gameRepo != nil {
+		go func(gameID string) {
+			if err := s.gameRepo.SaveAuction(gameID, record); err != nil {
+				log.Printf("Error saving auction %s: %v", record.AuctionID, err)
+			}
+		}(game.GameID)
+	}
+
+	game.ActiveAuction = nil
+	s.runBankAuctions(game)
+	s.broadcastGameState(game)
+}
+
+// checkOpenAuctionWinner makes sure the leader of an English or Dutch auction can still pay
+// their bid. If they went bankrupt or spent the money since, the property goes to the highest
+// earlier bid someone can still cover, at that bid, or stays with the bank.
+func (s *GameService) checkOpenAuctionWinner(game *domain.GameState) {
+	auction := game.ActiveAuction
+	canPay := func(id string, amount int) *domain.PlayerState {
+		p := s.getPlayer(game, id)
+		if p == nil || !p.IsActive || p.Balance < amount {
+			return nil
+		}
+		return p
+	}
+	if auction.BidderID == "" || canPay(auction.BidderID, auction.HighestBid) != nil {
+		return
+	}
+	s.addLog(game, auction.BidderName+" ya no puede pagar su oferta de $"+strconv.Itoa(auction.HighestBid), "ALERT")
+	leader := auction.BidderID
+	auction.BidderID, auction.BidderName = "", "No bids"
+
+	bids := append([]domain.AuctionBid(nil), auction.Bids...)
+	sort.SliceStable(bids, func(i, j int) bool { return bids[i].Amount > bids[j].Amount })
+	for _, b := range bids {
+		if b.PlayerID == leader {
+			continue
+		}
+		if p := canPay(b.PlayerID, b.Amount); p != nil {
+			auction.BidderID, auction.BidderName, auction.HighestBid = p.UserID, p.Name, b.Amount
+			s.addLog(game, "La subasta pasa a "+p.Name+" por su oferta de $"+strconv.Itoa(b.Amount), "INFO")
+			return
+		}
+	}
+}
+
+// GetAuctions returns the finished auctions of a game, also after it ended, to its players
+func (s *GameService) GetAuctions(gameID string, userID string) ([]domain.AuctionRecord, error) {
+	s.mu.RLock()
+	game, live := s.games[gameID]
+	player := live && s.getPlayer(game, userID) != nil
+	s.mu.RUnlock()
+
+	if s.gameRepo == nil {
+		return nil, errors.New("auction history is not available")
+	}
+	if !player && !live {
+		// Finished games are no longer in memory; their human players are on record
+		played, err := s.gameRepo.HasPlayer(gameID, userID)
+		if err != nil {
+			return nil, err
+		}
+		player = played
+	}
+	if !player {
+		return nil, errors.New("player not in game")
+	}
+	return s.gameRepo.ListAuctions(gameID)
+}
+
+// auctionFormat returns the format of an auction, ENGLISH for auctions started before formats existed
+func auctionFormat(auction *domain.AuctionState) string {
+	if auction.Format == "" {
+		return domain.AuctionEnglish
+	}
+	return auction.Format
+}
+
+// isSealedAuction reports whether the bids of an auction are hidden until the end
+func isSealedAuction(auction *domain.AuctionState) bool {
+	format := auctionFormat(auction)
+	return format == domain.AuctionSealedFirst || format == domain.AuctionSealedSecond
+}
+
+// dutchPrice is the current asking price of a Dutch auction
+func dutchPrice(auction *domain.AuctionState, now time.Time) int {
+	step := (auction.StartPrice - auction.FloorPrice) / domain.DutchDropSteps
+	drops := int(now.Sub(time.Unix(auction.StartTime, 0)) / domain.DutchDropInterval)
+	if drops >= domain.DutchDropSteps || step <= 0 {
+		return auction.FloorPrice
+	}
+	return auction.StartPrice - drops*step
+}
+
+// auctionBidders counts the active players still taking part in an auction
+func auctionBidders(game *domain.GameState) int {
+	n := 0
+	for _, p := range game.Players {
+		if p.IsActive && !game.ActiveAuction.PassedPlayers[p.UserID] {
+			n++
+		}
+	}
+	return n
+}
+
+// sealedBidsComplete reports whether every active player has bid or passed
+func sealedBidsComplete(game *domain.GameState) bool {
+	for _, p := range game.Players {
+		if !p.IsActive || game.ActiveAuction.PassedPlayers[p.UserID] {
+			continue
+		}
+		if _, ok := game.ActiveAuction.SealedBids[p.UserID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// openSealedBids reveals the envelopes and sets the winner and the price they pay. Bids the
+// bidder can no longer cover are discarded; ties go to the earliest bid.
+func (s *GameService) openSealedBids(game *domain.GameState) {
+	auction := game.ActiveAuction
+	type bid struct {
+		player *domain.PlayerState
+		amount int
+	}
+	var bids []bid
+	for _, id := range auction.Bidders {
+		amount, ok := auction.SealedBids[id]
+		p := s.getPlayer(game, id)
+		if !ok || p == nil || !p.IsActive || p.Balance < amount {
+			continue
+		}
+		bids = append(bids, bid{p, amount})
+	}
+	sort.SliceStable(bids, func(i, j int) bool { return bids[i].amount > bids[j].amount })
+
+	var opened []string
+	for _, b := range bids {
+		opened = append(opened, b.player.Name+" $"+strconv.Itoa(b.amount))
+	}
+	if len(opened) > 0 {
+		s.addLog(game, "✉️ Sobres abiertos: "+strings.Join(opened, ", "), "INFO")
+	}
+	if len(bids) == 0 {
+		return
+	}
+
+	price := bids[0].amount
+	if auctionFormat(auction) == domain.AuctionSealedSecond {
+		// Vickrey: the winner pays the second highest bid, or the minimum if unopposed
+		price = auction.HighestBid
+		if len(bids) > 1 {
+			price = bids[1].amount
+		}
+	}
+	auction.BidderID = bids[0].player.UserID
+	auction.BidderName = bids[0].player.Name
+	auction.HighestBid = price
+}
+
+// publicAuction is the auction as broadcast to the clients, without the sealed bids
+func publicAuction(auction *domain.AuctionState) *domain.AuctionState {
+	if auction == nil || !isSealedAuction(auction) {
+		return auction
+	}
+	public := *auction
+	public.SealedBids = nil
+	public.Bids = nil
+	return &public
+}
+
+// queueBankAuction adds a property to the bank's auction queue
+func queueBankAuction(game *domain.GameState, propertyID string) {
+	for _, id := range game.BankAuctionQueue {
+		if id == propertyID {
+			return
+		}
+	}
+	game.BankAuctionQueue = append(game.BankAuctionQueue, propertyID)
+}
+
+// scheduleBankAuction queues one unowned property every BankAuctions.EveryRounds table rounds
+func (s *GameService) scheduleBankAuction(game *domain.GameState) {
+	rules := game.Settings.BankAuctions
+	if rules == nil || rules.EveryRounds <= 0 || game.Round%rules.EveryRounds != 0 || len(game.BankAuctionQueue) > 0 {
+		return
+	}
+	var inventory []string
+	for _, t := range game.Board {
+		if _, owned := game.PropertyOwnership[t.PropertyID]; !owned && t.Price > 0 {
+			inventory = append(inventory, t.PropertyID)
+		}
+	}
+	if len(inventory) > 0 {
+		queueBankAuction(game, inventory[rand.Intn(len(inventory))])
+	}
+}
+
+// runBankAuctions starts the next queued bank auction when no auction is running. Properties
+// that found an owner in the meantime are dropped from the queue.
+func (s *GameService) runBankAuctions(game *domain.GameState) {
+	rules := game.Settings.BankAuctions
+	if rules == nil || game.Status != domain.GameStatusActive {
+		game.BankAuctionQueue = nil
+		return
+	}
+	if game.ActiveAuction != nil && game.ActiveAuction.IsActive {
+		return
+	}
+
+	reservePct := rules.ReservePercent
+	if reservePct <= 0 {
+		reservePct = domain.DefaultBankReservePct
+	}
+	for len(game.BankAuctionQueue) > 0 {
+		propertyID := game.BankAuctionQueue[0]
+		game.BankAuctionQueue = game.BankAuctionQueue[1:]
+
+		tile := s.propertyTile(game, propertyID)
+		if _, owned := game.PropertyOwnership[propertyID]; owned || tile == nil || tile.Price <= 0 {
+			continue
+		}
+		s.startAuction(game, tile, "el Banco", game.Settings.AuctionFormat, tile.Price*reservePct/100)
+		return
+	}
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+	"github.com/gabriel3312cl/finances-game/backend/internal/handler/websocket"
+)
+
+func TestAuction_VickreyWinnerPaysSecondPrice(t *testing.T) {
+	game := &domain.GameState{
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 500, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 500, IsActive: true},
+			{UserID: "c", Name: "C", Balance: 100, IsActive: true},
+		},
+		ActiveAuction: &domain.AuctionState{
+			Format:     domain.AuctionSealedSecond,
+			HighestBid: 10,
+			IsActive:   true,
+			Bidders:    []string{"a", "b", "c"},
+			SealedBids: map[string]int{"a": 150, "b": 220, "c": 300}, // C can't cover theirs anymore
+		},
+	}
+	s := &GameService{}
+	s.openSealedBids(game)
+
+	if game.ActiveAuction.BidderID != "b" || game.ActiveAuction.HighestBid != 150 {
+		t.Errorf("winner %s pays %d, want b paying 150", game.ActiveAuction.BidderID, game.ActiveAuction.HighestBid)
+	}
+	if publicAuction(game.ActiveAuction).SealedBids != nil {
+		t.Error("sealed bids must not be broadcast")
+	}
+}
+
+func TestAuction_DutchPriceRunsDown(t *testing.T) {
+	start := time.Now()
+	auction := &domain.AuctionState{StartTime: start.Unix(), StartPrice: 310, FloorPrice: 10}
+	if p := dutchPrice(auction, start); p != 310 {
+		t.Errorf("opening price %d, want 310", p)
+	}
+	if p := dutchPrice(auction, start.Add(3*domain.DutchDropInterval)); p != 250 {
+		t.Errorf("price after 3 drops %d, want 250", p)
+	}
+	if p := dutchPrice(auction, start.Add(time.Hour)); p != 10 {
+		t.Errorf("price never goes below the floor, got %d", p)
+	}
+}
+
+func TestAuction_MyGamesHideSealedBids(t *testing.T) {
+	game := &domain.GameState{
+		GameID:  "G1",
+		Players: []*domain.PlayerState{{UserID: "a", Name: "A"}, {UserID: "b", Name: "B"}},
+		ActiveAuction: &domain.AuctionState{
+			Format:     domain.AuctionSealedFirst,
+			IsActive:   true,
+			Bids:       []domain.AuctionBid{{PlayerID: "b", Amount: 220}},
+			Bidders:    []string{"b"},
+			SealedBids: map[string]int{"b": 220},
+		},
+	}
+	s := &GameService{games: map[string]*domain.GameState{"G1": game}}
+
+	games := s.GetGamesByUser("a")
+	if len(games) != 1 {
+		t.Fatalf("%d games, want 1", len(games))
+	}
+	if data, _ := json.Marshal(games[0]); strings.Contains(string(data), "220") {
+		t.Errorf("a rival's envelope leaked: %s", data)
+	}
+	if len(games[0].ActiveAuction.Bidders) != 1 {
+		t.Error("who has bid is public")
+	}
+	if game.ActiveAuction.SealedBids["b"] != 220 {
+		t.Error("hiding the bids must not touch the game")
+	}
+}
+
+func TestPublicView_HidesDeckOrder(t *testing.T) {
+	game := &domain.GameState{
+		GameID:    "G1",
+		Players:   []*domain.PlayerState{{UserID: "a", Name: "A"}},
+		CardDecks: map[string][]int{"CHANCE": {3, 1, 2}},
+	}
+	s := &GameService{hub: websocket.NewHub(), games: map[string]*domain.GameState{"G1": game}}
+
+	go s.broadcastGameState(game)
+	broadcast := <-s.hub.Broadcast
+	mine, _ := json.Marshal(s.GetGamesByUser("a"))
+	for path, data := range map[string][]byte{"broadcast": broadcast.Payload, "my games": mine} {
+		if strings.Contains(string(data), "card_decks") {
+			t.Errorf("%s shows the deck order: %s", path, data)
+		}
+	}
+	if len(game.CardDecks["CHANCE"]) != 3 {
+		t.Error("hiding the decks must not touch the game")
+	}
+}
+
+func TestAuction_FormatsValidateAndSettle(t *testing.T) {
+	type bid struct {
+		player string
+		amount int
+	}
+	for _, tc := range []struct {
+		name   string
+		format string
+		bids   []bid                                 // Bids the rules turn down are part of the script
+		before func(*GameService, *domain.GameState) // Runs between the bids and the close
+		owner  string                                // "" = unsold
+		price  int
+	}{
+		{name: "english highest bid wins", format: domain.AuctionEnglish,
+			bids:  []bid{{"a", 100}, {"b", 150}, {"a", 155}, {"c", 900}},
+			owner: "b", price: 150},
+		{name: "english leader went bankrupt", format: domain.AuctionEnglish,
+			bids:   []bid{{"a", 100}, {"b", 150}},
+			before: func(_ *GameService, g *domain.GameState) { g.Players[1].IsActive = false },
+			owner:  "a", price: 100},
+		{name: "english leader spent the money", format: domain.AuctionEnglish,
+			bids:   []bid{{"a", 100}, {"b", 150}},
+			before: func(s *GameService, g *domain.GameState) { s.bankCollect(g, g.Players[1], 380, domain.FlowTax, "") },
+			owner:  "a", price: 100},
+		{name: "english nobody can pay", format: domain.AuctionEnglish,
+			bids:   []bid{{"a", 100}},
+			before: func(s *GameService, g *domain.GameState) { s.bankCollect(g, g.Players[0], 450, domain.FlowTax, "") }},
+		{name: "dutch first taker wins", format: domain.AuctionDutch,
+			bids:  []bid{{"a", 0}, {"b", 0}},
+			owner: "a", price: 400},
+		{name: "dutch taker can't afford the price", format: domain.AuctionDutch,
+			bids: []bid{{"c", 0}}},
+		{name: "sealed first price", format: domain.AuctionSealedFirst,
+			bids:  []bid{{"a", 120}, {"b", 180}, {"c", 50}},
+			owner: "b", price: 180},
+		{name: "sealed second price", format: domain.AuctionSealedSecond,
+			bids:  []bid{{"a", 120}, {"b", 180}},
+			owner: "b", price: 120},
+		{name: "sealed winner went bankrupt", format: domain.AuctionSealedFirst,
+			bids:   []bid{{"a", 120}, {"b", 180}},
+			before: func(_ *GameService, g *domain.GameState) { g.Players[1].IsActive = false },
+			owner:  "a", price: 120},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			game := &domain.GameState{
+				Status: domain.GameStatusActive,
+				Players: []*domain.PlayerState{
+					{UserID: "a", Name: "A", Balance: 500, IsActive: true},
+					{UserID: "b", Name: "B", Balance: 500, IsActive: true},
+					{UserID: "c", Name: "C", Balance: 300, IsActive: true},
+				},
+				Board:             []domain.Tile{{PropertyID: "P1", Name: "Uno", Price: 200}},
+				PropertyOwnership: map[string]string{},
+			}
+			s := &GameService{}
+			s.initTreasury(game)
+			s.initLedger(game)
+			s.startAuction(game, &game.Board[0], "A", tc.format, 100)
+			balances := map[string]int{}
+			for _, p := range game.Players {
+				balances[p.UserID] = p.Balance
+			}
+
+			for _, b := range tc.bids {
+				if game.ActiveAuction != nil {
+					s.handleBid(game, b.player, []byte(`{"amount": `+strconv.Itoa(b.amount)+`}`))
+				}
+			}
+			if tc.before != nil {
+				tc.before(s, game)
+			}
+			s.endAuction(game)
+
+			if owner := game.PropertyOwnership["P1"]; owner != tc.owner {
+				t.Fatalf("P1 went to %q, want %q", owner, tc.owner)
+			}
+			if tc.owner != "" {
+				if paid := balances[tc.owner] - s.getPlayer(game, tc.owner).Balance; paid != tc.price {
+					t.Errorf("%s paid %d, want %d", tc.owner, paid, tc.price)
+				}
+			}
+			if err := s.checkInvariants(game); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestBankAuction_ForeclosuresGoUnderTheHammer(t *testing.T) {
+	owner := func(id string) *string { return &id }
+	game := &domain.GameState{
+		Status:        domain.GameStatusActive,
+		CurrentTurnID: "b",
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 10, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 500, IsActive: true},
+			{UserID: "c", Name: "C", Balance: 500, IsActive: true},
+		},
+		Board: []domain.Tile{
+			{PropertyID: "P1", Name: "Uno", Price: 200, OwnerID: owner("a")},
+			{PropertyID: "P2", Name: "Dos", Price: 300, OwnerID: owner("a"), IsMortgaged: true},
+			{PropertyID: "P3", Name: "Tres", Price: 100},
+		},
+		PropertyOwnership: map[string]string{"P1": "a", "P2": "a"},
+		Settings:          domain.GameSettings{BankAuctions: &domain.BankAuctionRules{Foreclosures: true, ReservePercent: 50}},
+	}
+	s := &GameService{}
+	s.initTreasury(game)
+	s.initLedger(game)
+
+	s.handleDeclareBankruptcy(game, "a")
+	auction := game.ActiveAuction
+	if auction == nil || auction.PropertyID != "P1" || auction.OpeningBid != 100 || len(game.BankAuctionQueue) != 1 {
+		t.Fatalf("foreclosure auction: %+v, queue %v", auction, game.BankAuctionQueue)
+	}
+
+	// Sold: the next foreclosure starts right away, clear of the old mortgage
+	s.handleBid(game, "b", []byte(`{"amount": 100}`))
+	s.endAuction(game)
+	if game.PropertyOwnership["P1"] != "b" || game.Players[1].Balance != 400 {
+		t.Fatalf("P1 owned by %q, B has %d", game.PropertyOwnership["P1"], game.Players[1].Balance)
+	}
+	if game.ActiveAuction == nil || game.ActiveAuction.PropertyID != "P2" || s.propertyTile(game, "P2").IsMortgaged {
+		t.Fatalf("second foreclosure auction: %+v", game.ActiveAuction)
+	}
+
+	// Nobody bids: the bank keeps it and the queue is done
+	s.endAuction(game)
+	if _, owned := game.PropertyOwnership["P2"]; owned || game.ActiveAuction != nil || len(game.BankAuctionQueue) != 0 {
+		t.Errorf("unsold auction left owner %q, auction %+v, queue %v", game.PropertyOwnership["P2"], game.ActiveAuction, game.BankAuctionQueue)
+	}
+	if err := s.checkInvariants(game); err != nil {
+		t.Error(err)
+	}
+
+	// Scheduled auctions pick from what the bank still holds
+	game.Settings.BankAuctions.EveryRounds = 1
+	s.scheduleBankAuction(game)
+	if len(game.BankAuctionQueue) != 1 || game.BankAuctionQueue[0] == "P1" {
+		t.Errorf("scheduled queue %v", game.BankAuctionQueue)
+	}
+}
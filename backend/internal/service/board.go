@@ -0,0 +1,267 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/boards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+	"github.com/gabriel3312cl/finances-game/backend/internal/repository/postgres"
+)
+
+// ErrInvalidBoard wraps the reasons a board definition is rejected by the admin API
+var ErrInvalidBoard = errors.New("tablero inválido")
+
+// loadBoards caches the custom board definitions next to the classic one. Runs at startup and
+// after every change made through the admin API; games keep the tiles they started with.
+func (s *GameService) loadBoards() {
+	custom, err := s.gameRepo.ListBoards()
+	if err != nil {
+		log.Printf("Error loading board definitions: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	boards := make(map[int]*domain.BoardDefinition)
+	if classic, ok := s.boards[0]; ok {
+		boards[0] = classic
+	}
+	for i := range custom {
+		boards[custom[i].ID] = &custom[i]
+	}
+	s.boards = boards
+	s.mu.Unlock()
+	log.Printf("Loaded %d custom boards", len(custom))
+}
+
+// classicBoard builds the definition of the classic board from the properties and board_layout
+// tables. Positions missing from the layout become "Unknown" tiles.
+func classicBoard(properties map[string]domain.Property, layout map[int]string) *domain.BoardDefinition {
+	def := &domain.BoardDefinition{
+		Name:    "Clásico",
+		Size:    domain.BoardSize,
+		Corners: domain.BoardCorners{Go: 0, Jail: 16, FreeParking: 32, GoToJail: 48},
+		Tiles:   make([]domain.BoardTile, domain.BoardSize),
+	}
+	groups := make(map[string]bool)
+	for i := range def.Tiles {
+		id, ok := layout[i]
+		if !ok {
+			def.Tiles[i] = domain.BoardTile{Type: "TILE", Name: "Unknown"}
+			continue
+		}
+		prop, isProperty := properties[id]
+		if !isProperty {
+			// Special tiles store their type in place of a property
+			def.Tiles[i] = domain.BoardTile{Type: id, Name: id}
+			continue
+		}
+		def.Tiles[i] = domain.BoardTile{Type: prop.Type, Name: prop.Name, Property: &prop}
+		if prop.GroupID != "" && !groups[prop.GroupID] {
+			groups[prop.GroupID] = true
+			def.Groups = append(def.Groups, domain.PropertyGroup{ID: prop.GroupID, Name: prop.GroupName, Color: prop.GroupColor})
+		}
+	}
+	return def
+}
+
+// boardDefinition returns the board a game is played on
+func (s *GameService) boardDefinition(game *domain.GameState) *domain.BoardDefinition {
+	if def, ok := s.boards[game.Settings.BoardID]; ok {
+		return def
+	}
+	return s.boards[0]
+}
+
+// catalogProperty returns a property of the game's board as defined, before any price index
+func (s *GameService) catalogProperty(game *domain.GameState, id string) (domain.Property, bool) {
+	def := s.boardDefinition(game)
+	if def == nil || id == "" {
+		return domain.Property{}, false
+	}
+	for _, bt := range def.Tiles {
+		if bt.Property != nil && boards.PropertyID(bt.Property) == id {
+			return *bt.Property, true
+		}
+	}
+	return domain.Property{}, false
+}
+
+// goPosition returns the board index of GO
+func goPosition(game *domain.GameState) int {
+	return max(domain.TilePosition(game.Board, domain.TileGo), 0)
+}
+
+// passesGo reports whether moving the given steps forward from a position reaches or crosses GO
+func passesGo(game *domain.GameState, from, steps int) bool {
+	n := len(game.Board)
+	if n == 0 {
+		return false
+	}
+	dist := ((goPosition(game)-from)%n + n) % n
+	if dist == 0 {
+		dist = n
+	}
+	return steps >= dist
+}
+
+// sendToJail moves a player straight to the jail tile
+func (s *GameService) sendToJail(game *domain.GameState, player *domain.PlayerState) {
+	jail := max(domain.TilePosition(game.Board, domain.TileJail), 0)
+	player.Position = jail
+	player.InJail = true
+	player.JailTurns = 0
+	s.trackTileVisit(game, player, jail)
+}
+
+// ListBoards returns the classic board followed by the custom ones
+func (s *GameService) ListBoards() []domain.BoardSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]int, 0, len(s.boards))
+	for id := range s.boards {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	list := []domain.BoardSummary{}
+	for _, id := range ids {
+		def := s.boards[id]
+		list = append(list, domain.BoardSummary{
+			ID: id, Name: def.Name, Description: def.Description, Size: def.Size,
+			IsDefault: id == 0, Valid: boards.Validate(def).Valid,
+		})
+	}
+	return list
+}
+
+// GetBoard returns a full board definition, ready to be exported as JSON
+func (s *GameService) GetBoard(id int) (*domain.BoardDefinition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	def, ok := s.boards[id]
+	if !ok {
+		return nil, postgres.ErrBoardNotFound
+	}
+	out := *def
+	out.ID = id
+	return &out, nil
+}
+
+// CreateBoard imports a custom board definition
+func (s *GameService) CreateBoard(def domain.BoardDefinition) (*domain.BoardDefinition, error) {
+	if err := checkBoard(&def); err != nil {
+		return nil, err
+	}
+	if err := s.gameRepo.CreateBoard(&def); err != nil {
+		return nil, err
+	}
+	s.loadBoards()
+	return &def, nil
+}
+
+// UpdateBoard replaces a custom board definition. The classic board and boards of running
+// games can't be changed.
+func (s *GameService) UpdateBoard(id int, def domain.BoardDefinition) (*domain.BoardDefinition, error) {
+	if err := s.checkBoardEditable(id); err != nil {
+		return nil, err
+	}
+	if err := checkBoard(&def); err != nil {
+		return nil, err
+	}
+	def.ID = id
+	if err := s.gameRepo.UpdateBoard(&def); err != nil {
+		return nil, err
+	}
+	s.loadBoards()
+	return &def, nil
+}
+
+// DeleteBoard removes a custom board definition that no running game uses
+func (s *GameService) DeleteBoard(id int) error {
+	if err := s.checkBoardEditable(id); err != nil {
+		return err
+	}
+	if err := s.gameRepo.DeleteBoard(id); err != nil {
+		return err
+	}
+	s.loadBoards()
+	return nil
+}
+
+func (s *GameService) checkBoardEditable(id int) error {
+	if id == 0 {
+		return fmt.Errorf("%w: el tablero clásico se edita en la base de datos", ErrInvalidBoard)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, g := range s.games {
+		if g.Settings.BoardID == id && g.Status != domain.GameStatusWaiting && g.Status != domain.GameStatusFinished {
+			return fmt.Errorf("%w: la partida %s se está jugando en este tablero", ErrInvalidBoard, g.GameID)
+		}
+	}
+	return nil
+}
+
+// InvalidBoardError carries every problem found in a rejected board definition
+type InvalidBoardError struct {
+	Problems []boards.Problem
+}
+
+func (e *InvalidBoardError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.String()
+	}
+	return ErrInvalidBoard.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *InvalidBoardError) Unwrap() error {
+	return ErrInvalidBoard
+}
+
+// ValidateBoard reports every problem of a board definition without saving it
+func (s *GameService) ValidateBoard(def domain.BoardDefinition) boards.Report {
+	normalizeBoard(&def)
+	return boards.Validate(&def)
+}
+
+// checkBoard normalizes a definition before it is stored and rejects the ones a game can't be
+// played on
+func checkBoard(def *domain.BoardDefinition) error {
+	normalizeBoard(def)
+	if def.Name == "" || len(def.Name) > 100 {
+		return fmt.Errorf("%w: el nombre debe tener entre 1 y 100 caracteres", ErrInvalidBoard)
+	}
+	if report := boards.Validate(def); !report.Valid {
+		return &InvalidBoardError{Problems: report.Problems}
+	}
+	return nil
+}
+
+// normalizeBoard fills the fields an author can leave out: the size, the type of each property
+// and the names of property tiles
+func normalizeBoard(def *domain.BoardDefinition) {
+	def.ID = 0
+	def.Name = strings.TrimSpace(def.Name)
+	if def.Size == 0 {
+		def.Size = len(def.Tiles)
+	}
+	for i := range def.Tiles {
+		t := &def.Tiles[i]
+		if t.Property == nil {
+			continue
+		}
+		if t.Property.Type == "" {
+			t.Property.Type = t.Type
+		}
+		if t.Name == "" {
+			t.Name = t.Property.Name
+		}
+	}
+}
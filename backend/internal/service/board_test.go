@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/boards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func TestBoard_CustomCornersDrivePositions(t *testing.T) {
+	def := domain.BoardDefinition{
+		Name:    "Barrio",
+		Corners: domain.BoardCorners{Go: 2, Jail: 4, FreeParking: 6, GoToJail: 8},
+		Groups:  []domain.PropertyGroup{{ID: "g1", Name: "Centro", Color: "#f00"}},
+	}
+	for i := 0; i < 10; i++ {
+		def.Tiles = append(def.Tiles, domain.BoardTile{Type: "CHANCE"})
+	}
+	def.Tiles[3] = domain.BoardTile{Type: "PROPERTY", Property: &domain.Property{Slug: "plaza", Name: "Plaza", GroupID: "g1", Price: 100}}
+	normalizeBoard(&def)
+
+	game := &domain.GameState{Board: boards.Build(&def)}
+	if game.Board[3].PropertyID != "plaza" || game.Board[3].GroupName != "Centro" || game.Board[3].Name != "Plaza" {
+		t.Errorf("property tile: %+v", game.Board[3])
+	}
+	if game.Board[8].Type != domain.TileGoToJail || goPosition(game) != 2 {
+		t.Errorf("corners not applied: %+v", game.Board[8])
+	}
+	if !passesGo(game, 9, 3) || passesGo(game, 9, 2) || !passesGo(game, 2, 10) {
+		t.Error("passing GO must be measured from the GO corner")
+	}
+
+	s := &GameService{}
+	player := &domain.PlayerState{UserID: "a"}
+	s.sendToJail(game, player)
+	if player.Position != 4 || !player.InJail {
+		t.Errorf("sent to %d, want the jail at 4", player.Position)
+	}
+
+	// A single avenue has no group to complete and no rent table
+	var invalid *InvalidBoardError
+	if err := checkBoard(&def); !errors.As(err, &invalid) || len(invalid.Problems) < 2 {
+		t.Errorf("want every problem of the board, got %v", err)
+	}
+}
@@ -8,10 +8,13 @@ import (
 	"log"
 	"math/rand"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/gabriel3312cl/finances-game/backend/internal/bots"
 	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+	"github.com/gabriel3312cl/finances-game/backend/internal/valuation"
 )
 
 type BotService struct {
@@ -22,7 +25,7 @@ type BotService struct {
 }
 
 func NewBotService(gameService *GameService, advisorService *AdvisorService, llmEndpoint string) *BotService {
-	return &BotService{
+	s := &BotService{
 		gameService:    gameService,
 		advisorService: advisorService,
 		llmEndpoint:    llmEndpoint,
@@ -30,75 +33,156 @@ func NewBotService(gameService *GameService, advisorService *AdvisorService, llm
 			Timeout: 30 * time.Second,
 		},
 	}
+	// Built-in brains; other strategies register from their own packages
+	bots.Register(bots.Heuristic, bots.StrategyFunc(s.heuristicDecision))
+	bots.Register(bots.LLM, bots.StrategyFunc(s.llmDecision))
+	return s
 }
 
-// GenerateDecision asks the LLM for the next move
-func (s *BotService) GenerateDecision(game *domain.GameState, botPlayer *domain.PlayerState) (*domain.BotAction, error) {
-	// Check Strategy
+// GenerateDecision asks the strategy of the bot's profile for its next move. Profiles naming a
+// strategy that isn't registered play the heuristic one.
+func (s *BotService) GenerateDecision(game *domain.GameState, botPlayer *domain.PlayerState) *domain.BotAction {
 	profile := domain.GetBotProfile(botPlayer.BotPersonalityID)
-	if profile.Strategy == "HEURISTIC" {
-		return s.generateHeuristicDecision(game, botPlayer)
+	strategy, ok := bots.Lookup(profile.Strategy)
+	if !ok {
+		log.Printf("Bot %s: unknown strategy %q, playing %s", botPlayer.Name, profile.Strategy, bots.Heuristic)
+		strategy = bots.StrategyFunc(s.heuristicDecision)
 	}
+	return strategy.Decide(bots.View{Game: game, Bot: botPlayer, Profile: profile, Rules: s.gameService.Simulator()})
+}
 
-	// 1. Build Prompt
-	prompt := s.buildRefinedBotPrompt(game, botPlayer)
-	// log.Printf("[BOT %s] Prompt generated: %s", botPlayer.Name, prompt)
+// maxLLMAttempts bounds the calls to the LLM for one decision; after that many rejected
+// answers the bot plays the heuristic strategy
+const maxLLMAttempts = 3
+
+// llmDecision is the built-in strategy asking the LLM for the next move. The LLM picks one of
+// the legal actions through function calling; answers the rules reject go back to it as the
+// function's result so it can correct itself.
+func (s *BotService) llmDecision(view bots.View) *domain.BotAction {
+	game, botPlayer := view.Game, view.Bot
+	// A Dutch clock doesn't wait for the LLM
+	if game.ActiveAuction != nil && game.ActiveAuction.IsActive && auctionFormat(game.ActiveAuction) == domain.AuctionDutch {
+		return s.auctionDecision(game, botPlayer)
+	}
+	tools := botTools(game, botPlayer, s.gameService.LegalActions(game, botPlayer.UserID))
+	if len(tools) == 0 {
+		return nil
+	}
+	sim, _ := view.Rules.(*Simulator)
 
-	// 2. Call LLM
 	messages := []ChatMessage{
-		{Role: "system", Content: prompt},
-		{Role: "user", Content: "Es tu turno. Analiza la situación y decide tu próxima acción. Responde SOLO con el JSON."},
+		{Role: "system", Content: s.buildRefinedBotPrompt(game, botPlayer)},
+		{Role: "user", Content: "Es tu turno. Analiza la situación y elige tu próxima acción llamando a una de las funciones."},
 	}
+	for attempt := 1; attempt <= maxLLMAttempts; attempt++ {
+		reply, err := s.callLLMMessage(LLMRequest{
+			Model:       "local-model",
+			Messages:    messages,
+			Temperature: 0.7, // Higher temp for personality variance
+			MaxTokens:   500,
+			Tools:       tools,
+			ToolChoice:  "required",
+		})
+		if err != nil {
+			log.Printf("Bot %s: LLM error: %v", botPlayer.Name, err)
+			break
+		}
+		messages = append(messages, *reply)
 
-	llmReq := LLMRequest{
-		Model:       "local-model",
-		Messages:    messages,
-		Temperature: 0.7, // Higher temp for personality variance
-		MaxTokens:   500,
-		Stream:      false,
+		action, callID, problem := llmReplyAction(reply, tools)
+		if problem == "" && sim != nil {
+			problem = sim.Try(game, botPlayer.UserID, action)
+		}
+		if problem == "" {
+			return action
+		}
+		log.Printf("Bot %s: LLM answer rejected (attempt %d/%d): %s", botPlayer.Name, attempt, maxLLMAttempts, problem)
+
+		feedback, _ := json.Marshal(map[string]any{
+			"ok":            false,
+			"error":         problem,
+			"legal_actions": toolNames(tools),
+		})
+		if callID != "" {
+			messages = append(messages, ChatMessage{Role: "tool", ToolCallID: callID, Content: string(feedback)})
+		} else {
+			messages = append(messages, ChatMessage{Role: "user", Content: "Acción rechazada: " + string(feedback) + ". Llama a una de las funciones."})
+		}
 	}
 
-	responseStr, err := s.callLLM(llmReq)
-	if err != nil {
-		return nil, err
+	action := s.heuristicDecision(view)
+	if action != nil {
+		log.Printf("Bot %s: no valid LLM action, playing %s", botPlayer.Name, bots.Heuristic)
 	}
+	return action
+}
 
-	// 3. Parse JSON
-	// Clean markdown code blocks if present
-	cleanJSON := cleanJSON(responseStr)
-
-	var action domain.BotAction
-	// Use Decoder to be more tolerant of trailing characters (like extra braces)
-	dec := json.NewDecoder(strings.NewReader(cleanJSON))
-	if err := dec.Decode(&action); err != nil {
-		return nil, fmt.Errorf("failed to parse bot JSON: %w (Response: %s)", err, cleanJSON)
+// llmReplyAction reads the action of an LLM reply: its first function call, or for models without
+// function calling, a JSON action in the text. It returns why the answer can't be played, if
+// it can't.
+func llmReplyAction(reply *ChatMessage, tools []LLMTool) (action *domain.BotAction, callID string, problem string) {
+	if len(reply.ToolCalls) > 0 {
+		call := reply.ToolCalls[0]
+		action, err := toolAction(call)
+		if err != nil {
+			return nil, call.ID, err.Error()
+		}
+		if !slices.Contains(toolNames(tools), action.Action) {
+			return nil, call.ID, fmt.Sprintf("%s no es una acción legal ahora", action.Action)
+		}
+		return action, call.ID, ""
 	}
 
-	return &action, nil
+	var parsed domain.BotAction
+	dec := json.NewDecoder(strings.NewReader(cleanJSON(reply.Content)))
+	if err := dec.Decode(&parsed); err != nil || parsed.Action == "" {
+		return nil, "", "No llamaste a ninguna función"
+	}
+	if !slices.Contains(toolNames(tools), parsed.Action) {
+		return nil, "", fmt.Sprintf("%s no es una acción legal ahora", parsed.Action)
+	}
+	return &parsed, "", ""
 }
 
-func (s *BotService) generateHeuristicDecision(game *domain.GameState, bot *domain.PlayerState) (*domain.BotAction, error) {
+// heuristicDecision is the built-in rule-based strategy
+func (s *BotService) heuristicDecision(view bots.View) *domain.BotAction {
+	game, bot := view.Game, view.Bot
+	traits := traitsFor(view.Profile)
+
 	// 0. Check for Bankruptcy condition
 	// 0. Check for Bankruptcy condition
 	if bot.Balance < 0 {
 		// Crisis Management: Try to liquidate assets
+		// 0. Use savings first
+		if bot.Savings > 0 {
+			return &domain.BotAction{Action: "WITHDRAW_SAVINGS", Payload: json.RawMessage(fmt.Sprintf(`{"amount": %d}`, bot.Savings)), Reason: "Necesito liquidez"}
+		}
 		// 1. Sell Hotels/Houses
 		for _, t := range game.Board {
 			if t.OwnerID != nil && *t.OwnerID == bot.UserID && t.BuildingCount > 0 {
-				return &domain.BotAction{Action: "SELL_BUILDING", Payload: json.RawMessage(fmt.Sprintf(`{"property_id": "%s"}`, t.PropertyID)), Reason: "Necesito liquidez"}, nil
+				return &domain.BotAction{Action: "SELL_BUILDING", Payload: json.RawMessage(fmt.Sprintf(`{"property_id": "%s"}`, t.PropertyID)), Reason: "Necesito liquidez"}
 			}
 		}
 		// 2. Mortgage Properties
 		for _, t := range game.Board {
 			if t.Type == "PROPERTY" || t.Type == "UTILITY" || t.Type == "RAILROAD" {
 				if t.OwnerID != nil && *t.OwnerID == bot.UserID && !t.IsMortgaged {
-					return &domain.BotAction{Action: "MORTGAGE_PROPERTY", Payload: json.RawMessage(fmt.Sprintf(`{"property_id": "%s"}`, t.PropertyID)), Reason: "Necesito liquidez"}, nil
+					return &domain.BotAction{Action: "MORTGAGE_PROPERTY", Payload: json.RawMessage(fmt.Sprintf(`{"property_id": "%s"}`, t.PropertyID)), Reason: "Necesito liquidez"}
 				}
 			}
 		}
 
 		// 3. If no assets left, surrender
-		return &domain.BotAction{Action: "DECLARE_BANKRUPTCY", Reason: "No tengo fondos para continuar."}, nil
+		return &domain.BotAction{Action: "DECLARE_BANKRUPTCY", Reason: "No tengo fondos para continuar."}
+	}
+
+	// Income tax choice: pay the cheaper option
+	if game.PendingTax != nil && game.PendingTax.PlayerID == bot.UserID {
+		option := domain.IncomeTaxFlat
+		if game.PendingTax.PercentAmount < game.PendingTax.FlatAmount {
+			option = domain.IncomeTaxPercent
+		}
+		return &domain.BotAction{Action: "PAY_INCOME_TAX", Payload: json.RawMessage(fmt.Sprintf(`{"option": "%s"}`, option)), Reason: "Pago la opción de impuesto más barata"}
 	}
 
 	// Simple Logic:
@@ -106,7 +190,10 @@ func (s *BotService) generateHeuristicDecision(game *domain.GameState, bot *doma
 	if game.CurrentTurnID == bot.UserID {
 		if game.Status == domain.GameStatusActive {
 			if game.Dice[0] == 0 {
-				return &domain.BotAction{Action: "ROLL_DICE", Reason: "Turno: Tirar dados"}, nil
+				if bot.InJail && bot.Inventory[domain.ItemJailFree] > 0 {
+					return &domain.BotAction{Action: "USE_ITEM", Payload: json.RawMessage(`{"item": "JAIL_FREE"}`), Reason: "Uso mi tarjeta para salir de la cárcel"}
+				}
+				return &domain.BotAction{Action: "ROLL_DICE", Reason: "Turno: Tirar dados"}
 			} else {
 				// Landed - Check mandatory actions first
 				currentTile := s.getTile(game, bot.Position)
@@ -115,67 +202,51 @@ func (s *BotService) generateHeuristicDecision(game *domain.GameState, bot *doma
 
 				// B. Draw Card?
 				if (currentTile.Type == "CHANCE" || currentTile.Type == "COMMUNITY") && game.DrawnCard == nil {
-					return &domain.BotAction{Action: "DRAW_CARD", Reason: "Casilla de suerte/comunidad"}, nil
+					return &domain.BotAction{Action: "DRAW_CARD", Reason: "Casilla de suerte/comunidad"}
 				}
 
 				// C. Buy Property - Check if tile is purchasable (has price) and unowned
 				if currentTile.Price > 0 && currentTile.OwnerID == nil {
-					log.Printf("Bot %s on purchasable tile %s (type=%s, price=%d, balance=%d)",
-						bot.Name, currentTile.Name, currentTile.Type, currentTile.Price, bot.Balance)
-					// Logic: Buy if have money >= price
-					if bot.Balance >= currentTile.Price {
-						return &domain.BotAction{Action: "BUY_PROPERTY", Reason: "Tengo dinero, compro."}, nil
+					// Buy while keeping the personality's cash reserve, otherwise try to get it cheaper at auction
+					if traits.wantsToBuy(game, bot, currentTile) {
+						return &domain.BotAction{Action: "BUY_PROPERTY", Reason: "Tengo dinero, compro."}
+					} else if bot.Balance >= currentTile.Price {
+						return &domain.BotAction{Action: "START_AUCTION", Reason: "Prefiero guardar efectivo, inicio subasta."}
 					} else {
 						// Auction
-						return &domain.BotAction{Action: "START_AUCTION", Reason: "No tengo dinero, inicio subasta."}, nil
+						return &domain.BotAction{Action: "START_AUCTION", Reason: "No tengo dinero, inicio subasta."}
 					}
 				}
 
 				// D. Construction Phase (Buy Buildings) - Priority over ending turn
-				// Check if we own any full Monopoly and have surplus cash
-				if bot.Balance > 300 { // Lower threshold to encourage building
-					for _, t := range game.Board {
-						if t.OwnerID != nil && *t.OwnerID == bot.UserID && t.GroupIdentifier != "" && !t.IsMortgaged {
-							// Check if full group owned
-							allOwned := true
-							minBuild := 10 // Start high
-							// Verify group ownership and find min build level
-							for _, other := range game.Board {
-								if other.GroupIdentifier == t.GroupIdentifier {
-									if other.OwnerID == nil || *other.OwnerID != bot.UserID {
-										allOwned = false
-										break
-									}
-									if other.BuildingCount < minBuild {
-										minBuild = other.BuildingCount
-									}
-								}
-							}
-
-							if allOwned {
-								// Check "Even Build" rule: We can build on 't' if t.BuildingCount == minBuild
-								// And limit < 5
-								if t.BuildingCount == minBuild && t.BuildingCount < 5 {
-									cost := t.HouseCost
-									if t.BuildingCount == 4 {
-										cost = t.HotelCost
-									} // Assuming same
-
-									if bot.Balance > cost+150 { // Keep smaller safety buffer
-										return &domain.BotAction{
-											Action:  "BUY_BUILDING",
-											Payload: json.RawMessage(fmt.Sprintf(`{"property_id": "%s"}`, t.PropertyID)),
-											Reason:  fmt.Sprintf("Inversión en casas para %s", t.Name),
-										}, nil
-									}
-								}
-							}
+				// Build where the rules allow it while keeping the personality's reserve
+				canBuild := make(map[string]bool)
+				for _, legal := range s.gameService.LegalActions(game, bot.UserID) {
+					if legal.Action == "BUY_BUILDING" {
+						for _, id := range legal.PropertyIDs {
+							canBuild[id] = true
+						}
+					}
+				}
+				for _, t := range game.Board {
+					if !canBuild[t.PropertyID] {
+						continue
+					}
+					cost := t.HouseCost
+					if t.BuildingCount == 4 {
+						cost = t.HotelCost
+					}
+					if bot.Balance-cost >= traits.buildReserve {
+						return &domain.BotAction{
+							Action:  "BUY_BUILDING",
+							Payload: json.RawMessage(fmt.Sprintf(`{"property_id": "%s"}`, t.PropertyID)),
+							Reason:  fmt.Sprintf("Inversión en casas para %s", t.Name),
 						}
 					}
 				}
 
 				// E. Trade Proposal - Increase chance and logic
-				if game.ActiveTrade == nil && rand.Float64() < 0.40 { // 40% chance to consider trade before ending turn
+				if !hasSentTrade(game, bot.UserID) && rand.Float64() < traits.tradeChance {
 					// Find a property we want (part of a group we partially own)
 					wantedProps := []domain.Tile{}
 					myGroups := make(map[string]int)
@@ -232,10 +303,26 @@ func (s *BotService) generateHeuristicDecision(game *domain.GameState, bot *doma
 							if bot.Balance > 300 {
 								offerCash = 50
 							}
-						} else if bot.Balance > target.Price*2 {
-							// Cash only offer (aggressive)
-							offerCash = target.Price + 100 + rand.Intn(200) // Price + premium
-							canOffer = true
+						} else {
+							// Cash only offer: price + premium, as long as it leaves the reserve
+							offerCash = target.Price + rand.Intn(int(float64(target.Price)*traits.tradePremium)+1)
+							canOffer = bot.Balance-offerCash >= traits.cashReserve
+						}
+
+						// Sweeten the offer until the owner doesn't lose by it, as long as it still pays off for me
+						if canOffer {
+							score := valuation.ScoreTrade(game, []domain.TradeLeg{
+								{FromID: bot.UserID, ToID: targetOwnerID, Properties: offerProps, Cash: offerCash},
+								{FromID: targetOwnerID, ToID: bot.UserID, Properties: []string{target.PropertyID}},
+							}, nil)
+							extra := 0
+							if owner := score.Side(targetOwnerID); owner != nil && owner.Net < 0 {
+								extra = -owner.Net
+							}
+							offerCash += extra
+							if mine := score.Side(bot.UserID); bot.Balance-offerCash < traits.cashReserve/2 || mine == nil || mine.Net-extra < 0 {
+								canOffer = false
+							}
 						}
 
 						if canOffer {
@@ -246,31 +333,84 @@ func (s *BotService) generateHeuristicDecision(game *domain.GameState, bot *doma
 								Action:  "INITIATE_TRADE",
 								Payload: json.RawMessage(payload),
 								Reason:  fmt.Sprintf("Quiero completar mi monopolio de %s", target.GroupName),
-							}, nil
+							}
 						}
 					}
 				}
 
+				// E2. Roll again with a held item
+				if bot.Inventory[domain.ItemMoveAgain] > 0 && !bot.InJail && game.Dice[0] != game.Dice[1] {
+					return &domain.BotAction{Action: "USE_ITEM", Payload: json.RawMessage(`{"item": "MOVE_AGAIN"}`), Reason: "Uso mi objeto para tirar de nuevo"}
+				}
+
 				// F. End Turn
 				// Rent is now automatic, no need to check for pending rent
-				return &domain.BotAction{Action: "END_TURN", Reason: "Fin de turno"}, nil
+				return &domain.BotAction{Action: "END_TURN", Reason: "Fin de turno"}
 			}
 		}
 	} else if game.ActiveAuction != nil && game.ActiveAuction.IsActive {
-		// Auction Logic
-		limit := 500 // Hardcoded limit for now
-		if bot.Balance < limit {
-			limit = bot.Balance
-		}
+		return s.auctionDecision(game, bot)
+	}
+
+	return nil
+}
+
+// auctionLimit is the most a bot will pay in an auction: what the property is worth to it,
+// scaled by its personality's bid fraction, within its cash
+func auctionLimit(game *domain.GameState, bot *domain.PlayerState) int {
+	limit := bot.Balance
+	traits := traitsFor(domain.GetBotProfile(bot.BotPersonalityID))
+	if v := valuation.Property(game, bot.UserID, game.ActiveAuction.PropertyID); v != nil {
+		limit = min(limit, int(float64(v.Value)*traits.bidFraction))
+	}
+	return limit
+}
 
-		minBid := game.ActiveAuction.HighestBid + 10
-		if minBid <= limit {
-			return &domain.BotAction{Action: "BID", Amount: minBid, Reason: "Pugna automática"}, nil
+// shadedBid lowers a bid below the bot's value for pay-your-bid formats: with n bidders the
+// equilibrium bid is value*(n-1)/n
+func shadedBid(game *domain.GameState, value int) int {
+	n := max(auctionBidders(game), 2)
+	return value * (n - 1) / n
+}
+
+// auctionDecision bids according to the auction format
+func (s *BotService) auctionDecision(game *domain.GameState, bot *domain.PlayerState) *domain.BotAction {
+	auction := game.ActiveAuction
+	limit := auctionLimit(game, bot)
+
+	switch auctionFormat(auction) {
+	case domain.AuctionSealedFirst:
+		if bid := shadedBid(game, limit); bid >= auction.HighestBid {
+			return &domain.BotAction{Action: "BID", Amount: bid, Reason: "Oferta en sobre algo por debajo de lo que vale para mí"}
+		}
+	case domain.AuctionSealedSecond:
+		// Paying the second price, bidding the true value is the best strategy
+		if limit >= auction.HighestBid {
+			return &domain.BotAction{Action: "BID", Amount: limit, Reason: "Oferto lo que vale para mí"}
+		}
+	case domain.AuctionDutch:
+		if price := dutchPrice(auction, time.Now()); price <= shadedBid(game, limit) {
+			return &domain.BotAction{Action: "BID", Amount: price, Reason: fmt.Sprintf("Acepto el precio de $%d", price)}
+		}
+	default:
+		if minBid := minNextBid(auction); minBid <= limit {
+			return &domain.BotAction{Action: "BID", Amount: minBid, Reason: "Pugna automática"}
 		}
-		return &domain.BotAction{Action: "PASS_AUCTION", Reason: "Muy caro"}, nil
+	}
+	return &domain.BotAction{Action: "PASS_AUCTION", Reason: "Muy caro"}
+}
+
+// acceptsTrade decides on an offer waiting for a bot's approval: it accepts when the value it
+// receives, valued for the bot, reaches its personality's share of what it gives. Skilled
+// negotiators hold out for a profit, naive ones settle for less.
+func (s *BotService) acceptsTrade(game *domain.GameState, bot *domain.PlayerState, trade *domain.TradeOffer) bool {
+	offerValue, requestValue := 0, 0
+	if side := scoreTrade(game, trade).Side(bot.UserID); side != nil {
+		offerValue, requestValue = side.Receives, side.Gives
 	}
 
-	return nil, fmt.Errorf("no heuristic action found")
+	traits := traitsFor(domain.GetBotProfile(bot.BotPersonalityID))
+	return offerValue > 0 && float64(offerValue) >= float64(requestValue)*traits.acceptRatio
 }
 
 func toJSONList(items []string) string {
@@ -296,69 +436,12 @@ func (s *BotService) buildRefinedBotPrompt(game *domain.GameState, bot *domain.P
 	sb.WriteString("=== ESTADO DEL JUEGO ===\n")
 	sb.WriteString(baseInfo)
 
-	sb.WriteString("\n=== TUS POSIBLES ACCIONES ===\n")
-	sb.WriteString("Debes elegir UNA acción válida. Responde estricamente en formato JSON.\n")
-	sb.WriteString(`Formato: { "action": "ACTION_NAME", "payload": { ...params... }, "reason": "Breve justificación" }`)
-	sb.WriteString("\n\n")
-
-	// Filter valid actions based on game state
-	possibleActions := []string{}
-
-	if game.CurrentTurnID == bot.UserID {
-		// Detect Bankruptcy Scenario
-		if bot.Balance < 0 {
-			possibleActions = append(possibleActions, `{"action": "DECLARE_BANKRUPTCY", "reason": "Estoy en deuda y no puedo pagar"}`)
-			// Also suggest selling assets if implemented? For now just offer surrender.
-		}
-
-		// My Turn
-		if game.Status == domain.GameStatusActive {
-			// Check Phase
-			if game.Dice[0] == 0 {
-				possibleActions = append(possibleActions, `{"action": "ROLL_DICE", "reason": "Es mi turno y debo tirar"}`)
-			} else {
-				// Dice rolled, check events
-
-				// Pending Rent Collection
-				if game.PendingRent != nil && game.PendingRent.CreditorID == bot.UserID {
-					possibleActions = append(possibleActions, `{"action": "COLLECT_RENT", "reason": "Cobrar renta pendiente"}`)
-				} else {
-					// Landing checks
-					currentTile := s.getTile(game, bot.Position)
-
-					// Draw Card
-					if (currentTile.Type == "CHANCE" || currentTile.Type == "COMMUNITY") && game.DrawnCard == nil {
-						possibleActions = append(possibleActions, `{"action": "DRAW_CARD", "reason": "Tengo que sacar carta"}`)
-					}
-
-					if currentTile.Type == "PROPERTY" && currentTile.OwnerID == nil {
-						if bot.Balance >= currentTile.Price {
-							possibleActions = append(possibleActions, `{"action": "BUY_PROPERTY", "reason": "Tengo dinero y quiero invertir"}`)
-						} else {
-							possibleActions = append(possibleActions, `{"action": "START_AUCTION", "reason": "No me alcanza el dinero"}`)
-						}
-						possibleActions = append(possibleActions, `{"action": "START_AUCTION", "reason": "Es muy cara o no me interesa"}`)
-					} else {
-						possibleActions = append(possibleActions, `{"action": "END_TURN", "reason": "Ya no hay nada que hacer"}`)
-					}
-				}
-			}
-		}
-	} else if game.ActiveAuction != nil && game.ActiveAuction.IsActive {
-		// Auction Phase
-		minBid := game.ActiveAuction.HighestBid + 10
-		if bot.Balance >= minBid {
-			possibleActions = append(possibleActions, fmt.Sprintf(`{"action": "BID", "amount": %d, "reason": "Quiero ganar esta propiedad"}`, minBid))
-		}
-		if game.ActiveAuction.BidderID != bot.UserID {
-			// Only pass if not winning
-			possibleActions = append(possibleActions, `{"action": "PASS_AUCTION", "reason": "Muy caro para mi"}`)
-		}
-	}
-
-	sb.WriteString("Opciones sugeridas (no limitativas, pero PRIORIZA estas si son validas):\n")
-	for _, act := range possibleActions {
-		sb.WriteString("- " + act + "\n")
+	sb.WriteString("\n=== CÓMO JUGAR ===\n")
+	sb.WriteString("Elige UNA acción llamando a una de las funciones disponibles: son las acciones legales en este momento.\n")
+	sb.WriteString("Explica tu decisión en el argumento 'reason'. Si una acción es rechazada, recibirás el motivo; elige otra.\n")
+	if auction := game.ActiveAuction; auction != nil && auction.IsActive && isSealedAuction(auction) {
+		sb.WriteString(fmt.Sprintf("SUBASTA EN SOBRE CERRADO (%s): ofertas ocultas, solo puedes ofertar una vez. Valor estimado para ti: $%d\n",
+			auction.Format, auctionLimit(game, bot)))
 	}
 
 	return sb.String()
@@ -375,44 +458,53 @@ func (s *BotService) getTile(game *domain.GameState, pos int) domain.Tile {
 }
 
 func (s *BotService) callLLM(req LLMRequest) (string, error) {
+	reply, err := s.callLLMMessage(req)
+	if err != nil {
+		return "", err
+	}
+	return reply.Content, nil
+}
+
+// callLLMMessage returns the whole reply of the LLM, with the functions it called
+func (s *BotService) callLLMMessage(req LLMRequest) (*ChatMessage, error) {
 	// Re-implementing callLLM to keep services decoupled enough or import from advisor if exported
 	// For now copy-paste logic as callLLM in Advisor is private, but I can make it public or just copy.
 	// Copying for stability.
 	jsonData, err := json.Marshal(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	httpReq, err := http.NewRequest("POST", s.llmEndpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	resp, err := s.httpClient.Do(httpReq)
 	if err != nil {
-		return "", fmt.Errorf("failed to call LLM: %w", err)
+		return nil, fmt.Errorf("failed to call LLM: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("LLM returned status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("LLM returned status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var llmResp LLMResponse
 	if err := json.Unmarshal(body, &llmResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 	if len(llmResp.Choices) == 0 {
-		return "", fmt.Errorf("no response from LLM")
+		return nil, fmt.Errorf("no response from LLM")
 	}
 
-	return llmResp.Choices[0].Message.Content, nil
+	return &llmResp.Choices[0].Message, nil
 }
 
 // GenerateChatResponse generates a contextual chat response with bot personality
@@ -473,6 +565,19 @@ func (s *BotService) GenerateChatResponse(game *domain.GameState, bot *domain.Pl
 		}
 	}
 
+	// Open offers waiting for the bot
+	if incoming := incomingTrades(game, bot.UserID); len(incoming) > 0 {
+		gameContext.WriteString("\nOFERTAS PENDIENTES PARA TI:\n")
+		for _, t := range incoming {
+			gameContext.WriteString(fmt.Sprintf("- trade_id=%s de %s: te da %v + $%d, pide %v + $%d",
+				t.ID, t.OffererName, t.OfferPropeties, t.OfferCash, t.RequestProperties, t.RequestCash))
+			if side := scoreTrade(game, t).Side(bot.UserID); side != nil {
+				gameContext.WriteString(fmt.Sprintf(" (valor para ti: recibes ~$%d, entregas ~$%d)", side.Receives, side.Gives))
+			}
+			gameContext.WriteString("\n")
+		}
+	}
+
 	// Build the prompt
 	systemPrompt := fmt.Sprintf(`Eres un bot de IA jugando Monopoly llamado "%s".
 %s
@@ -498,6 +603,9 @@ ACCIONES DISPONIBLES:
 2. ACCEPT_TRADE: Si hay una oferta activa hacia ti (revisar estado).
    { "action": "ACCEPT_TRADE", "payload": { "trade_id": "ID_TRADE" } }
 
+3. COUNTER_TRADE: Para responder una oferta con otras condiciones (tú pasas a ser quien ofrece).
+   { "action": "COUNTER_TRADE", "payload": { "trade_id": "ID_TRADE", "offer_properties": [], "offer_cash": 0, "request_properties": [], "request_cash": 50 } }
+
 CONTEXTO ACTUAL:
 %s`, bot.Name, profile.Description, gameContext.String())
 
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/bots"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func TestBotAction_PayloadAndDispatch(t *testing.T) {
+	game := &domain.GameState{Board: []domain.Tile{{PropertyID: "GO"}, {PropertyID: "P1", Price: 100}}}
+	bot := &domain.PlayerState{UserID: "BOT_1", Name: "Bot", Position: 1}
+
+	for _, tc := range []struct {
+		action *domain.BotAction
+		want   string
+	}{
+		{&domain.BotAction{Action: "BUY_PROPERTY"}, `{"property_id": "P1"}`},
+		{&domain.BotAction{Action: "BID", Amount: 120}, `{"amount": 120}`},
+		{&domain.BotAction{Action: "UNMORTGAGE_PROPERTY", PropertyID: "P9"}, `{"property_id": "P9"}`},
+		{&domain.BotAction{Action: "TAKE_LOAN", Payload: []byte(`{"amount": 300}`)}, `{"amount": 300}`},
+		{&domain.BotAction{Action: "PAY_BAIL"}, ``},
+	} {
+		if got := string(botPayload(game, bot, tc.action)); got != tc.want {
+			t.Errorf("%s: payload %q, want %q", tc.action.Action, got, tc.want)
+		}
+	}
+
+	s := &GameService{}
+	if s.dispatch(game, bot.UserID, "FLY_AWAY", nil) {
+		t.Error("unknown actions must be reported")
+	}
+}
+
+func TestBotView_HidesWhatTheSeatCantSee(t *testing.T) {
+	deck := make([]int, 20)
+	for i := range deck {
+		deck[i] = i + 1
+	}
+	game := &domain.GameState{
+		Players: []*domain.PlayerState{{UserID: "BOT_1"}, {UserID: "b"}},
+		ActiveAuction: &domain.AuctionState{
+			Format:     domain.AuctionSealedFirst,
+			IsActive:   true,
+			Bids:       []domain.AuctionBid{{PlayerID: "BOT_1", Amount: 150}, {PlayerID: "b", Amount: 220}},
+			SealedBids: map[string]int{"BOT_1": 150, "b": 220},
+		},
+		CardDecks: map[string][]int{"CHANCE": deck},
+	}
+
+	view := botView(game, "BOT_1")
+	if data, _ := json.Marshal(view.ActiveAuction); strings.Contains(string(data), "220") {
+		t.Errorf("the bot sees a rival's envelope: %s", data)
+	}
+	if view.ActiveAuction.SealedBids["BOT_1"] != 150 {
+		t.Error("the bot must still know its own bid")
+	}
+	drawn := view.CardDecks["CHANCE"]
+	if slices.Equal(drawn, deck) || !slices.Equal(slices.Sorted(slices.Values(drawn)), deck) {
+		t.Errorf("deck %v must hold the same cards in a new order", drawn)
+	}
+	if game.ActiveAuction.SealedBids["b"] != 220 || !slices.IsSorted(game.CardDecks["CHANCE"]) {
+		t.Error("the view must not touch the game")
+	}
+}
+
+func TestHeuristic_PersonalityDrivesPurchases(t *testing.T) {
+	game := &domain.GameState{
+		Status:            domain.GameStatusActive,
+		CurrentTurnID:     "BOT_1",
+		Dice:              [2]int{1, 2},
+		Board:             []domain.Tile{{ID: 0, PropertyID: "GO"}, {ID: 1, PropertyID: "P1", Name: "Plaza", Type: "PROPERTY", Price: 200}},
+		PropertyOwnership: map[string]string{},
+	}
+	bot := &domain.PlayerState{UserID: "BOT_1", Position: 1, Balance: 420, IsActive: true}
+	game.Players = []*domain.PlayerState{bot}
+
+	s := &BotService{gameService: &GameService{}}
+	for id, want := range map[string]string{"tycoon": "BUY_PROPERTY", "saver": "START_AUCTION"} {
+		view := bots.View{Game: game, Bot: bot, Profile: domain.GetBotProfile(id)}
+		if got := s.heuristicDecision(view); got.Action != want {
+			t.Errorf("%s: %s (%s), want %s", id, got.Action, got.Reason, want)
+		}
+	}
+
+	saver, tycoon := traitsFor(domain.GetBotProfile("saver")), traitsFor(domain.GetBotProfile("tycoon"))
+	if saver.bidFraction >= tycoon.bidFraction || saver.buildReserve <= tycoon.buildReserve {
+		t.Errorf("the saver must bid lower and build later than the tycoon: %+v vs %+v", saver, tycoon)
+	}
+}
+
+func TestLLMDecision_RetriesWithFeedbackThenFallsBack(t *testing.T) {
+	game := &domain.GameState{
+		Status:            domain.GameStatusActive,
+		CurrentTurnID:     "BOT_1",
+		Board:             []domain.Tile{{ID: 0, PropertyID: "GO"}, {ID: 1, PropertyID: "P1", Name: "Plaza", Type: "PROPERTY", Price: 200}},
+		PropertyOwnership: map[string]string{},
+	}
+	bot := &domain.PlayerState{UserID: "BOT_1", Name: "Bot", Balance: 500, IsActive: true, BotPersonalityID: "balanced"}
+	game.Players = []*domain.PlayerState{bot}
+
+	// The LLM first calls an action that isn't legal before rolling, then rolls
+	var requests []LLMRequest
+	answers := []string{"BUY_BUILDING", "ROLL_DICE"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req LLMRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+		name := answers[min(len(requests), len(answers))-1]
+		fmt.Fprintf(w, `{"choices":[{"message":{"role":"assistant","content":"","tool_calls":[{"id":"call_%d","type":"function","function":{"name":"%s","arguments":"{\"reason\":\"Vamos\"}"}}]}}]}`, len(requests), name)
+	}))
+	defer server.Close()
+
+	s := &BotService{gameService: &GameService{}, advisorService: &AdvisorService{}, llmEndpoint: server.URL, httpClient: server.Client()}
+	view := bots.View{Game: game, Bot: bot, Profile: domain.GetBotProfile("balanced")}
+	action := s.llmDecision(view)
+	if action == nil || action.Action != "ROLL_DICE" || action.Reason != "Vamos" {
+		t.Fatalf("action: %+v", action)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("%d calls to the LLM, want 2", len(requests))
+	}
+	if names := toolNames(requests[0].Tools); len(names) == 0 || names[0] != "ROLL_DICE" || slices.Contains(names, "BUY_BUILDING") {
+		t.Errorf("tools before rolling: %v", names)
+	}
+	feedback := requests[1].Messages[len(requests[1].Messages)-1]
+	if feedback.Role != "tool" || feedback.ToolCallID != "call_1" || !strings.Contains(feedback.Content, "BUY_BUILDING no es una acción legal") {
+		t.Errorf("feedback: %+v", feedback)
+	}
+
+	// An LLM that never gets it right hands the decision to the heuristic
+	requests, answers = nil, []string{"FLY_AWAY"}
+	if action := s.llmDecision(view); action == nil || action.Action != "ROLL_DICE" || len(requests) != maxLLMAttempts {
+		t.Errorf("fallback: %+v after %d calls", action, len(requests))
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// botAnswers are the legal actions bots don't get as tools: they answer trade offers with
+// acceptsTrade as soon as they arrive
+var botAnswers = map[string]bool{
+	"ACCEPT_TRADE":  true,
+	"REJECT_TRADE":  true,
+	"COUNTER_TRADE": true,
+}
+
+// botTools turns the legal actions of a bot into functions for the LLM to call, each with the
+// schema of its payload. Arguments always include the reason shown in the chat.
+func botTools(game *domain.GameState, bot *domain.PlayerState, legal []domain.LegalAction) []LLMTool {
+	var tools []LLMTool
+	for _, a := range legal {
+		if botDeniedActions[a.Action] || botAnswers[a.Action] {
+			continue
+		}
+		properties := map[string]any{
+			"reason": map[string]any{"type": "string", "description": "Breve justificación, en tu personalidad"},
+		}
+		required := []string{"reason"}
+		param := func(name string, schema map[string]any) {
+			properties[name] = schema
+			required = append(required, name)
+		}
+		if len(a.PropertyIDs) > 0 {
+			param("property_id", map[string]any{"type": "string", "enum": a.PropertyIDs})
+		}
+		if a.MaxAmount > 0 {
+			param("amount", map[string]any{"type": "integer", "minimum": a.MinAmount, "maximum": a.MaxAmount})
+		}
+		if len(a.Options) > 0 {
+			name := "option"
+			if a.Action == "USE_ITEM" {
+				name = "item"
+			}
+			param(name, map[string]any{"type": "string", "enum": a.Options})
+		}
+		if len(a.TargetIDs) > 0 {
+			param("target_id", map[string]any{"type": "string", "enum": a.TargetIDs})
+			for name, schema := range tradeParams(game, bot, a.TargetIDs) {
+				properties[name] = schema
+			}
+		}
+		tools = append(tools, LLMTool{Type: "function", Function: LLMFunction{
+			Name:        a.Action,
+			Description: a.Description,
+			Parameters:  map[string]any{"type": "object", "properties": properties, "required": required},
+		}})
+	}
+	return tools
+}
+
+// tradeParams is the schema of the terms of a trade proposal
+func tradeParams(game *domain.GameState, bot *domain.PlayerState, targets []string) map[string]any {
+	unbuilt := func(t domain.Tile) bool { return t.BuildingCount == 0 }
+	var theirs []string
+	for _, id := range targets {
+		theirs = append(theirs, ownedProperties(game, id, unbuilt)...)
+	}
+	list := func(ids []string) map[string]any {
+		if len(ids) == 0 {
+			return map[string]any{"type": "array", "maxItems": 0}
+		}
+		return map[string]any{"type": "array", "items": map[string]any{"type": "string", "enum": ids}}
+	}
+	return map[string]any{
+		"offer_properties":   list(ownedProperties(game, bot.UserID, unbuilt)),
+		"offer_cash":         map[string]any{"type": "integer", "minimum": 0, "maximum": max(bot.Balance, 0)},
+		"request_properties": list(theirs),
+		"request_cash":       map[string]any{"type": "integer", "minimum": 0},
+	}
+}
+
+// toolNames lists the names of the tools, for feedback on calls outside them
+func toolNames(tools []LLMTool) []string {
+	names := make([]string, len(tools))
+	for i, t := range tools {
+		names[i] = t.Function.Name
+	}
+	return names
+}
+
+// toolAction turns a function call of the LLM into a bot action: the arguments other than the
+// reason are the action's payload
+func toolAction(call LLMToolCall) (*domain.BotAction, error) {
+	var args map[string]json.RawMessage
+	if strings.TrimSpace(call.Function.Arguments) != "" {
+		if err := json.Unmarshal([]byte(call.Function.Arguments), &args); err != nil {
+			return nil, fmt.Errorf("los argumentos no son un objeto JSON: %v", err)
+		}
+	}
+	action := &domain.BotAction{Action: call.Function.Name}
+	if reason, ok := args["reason"]; ok {
+		json.Unmarshal(reason, &action.Reason)
+		delete(args, "reason")
+	}
+	if id, ok := args["property_id"]; ok {
+		json.Unmarshal(id, &action.PropertyID)
+	}
+	if amount, ok := args["amount"]; ok {
+		json.Unmarshal(amount, &action.Amount)
+	}
+	if len(args) > 0 {
+		action.Payload, _ = json.Marshal(args)
+	}
+	return action, nil
+}
+
+// Try plays an action on a copy of a game and returns why the rules refuse it, or "" when it
+// goes through. An action that leaves the game as it was counts as refused, with the alerts
+// the handlers logged as the reason.
+func (sim *Simulator) Try(game *domain.GameState, userID string, action *domain.BotAction) string {
+	clone := sim.Clone(game)
+	if clone == nil {
+		return ""
+	}
+	before, _ := json.Marshal(clone)
+	last := clone.LastAction
+	sim.Apply(clone, userID, action)
+
+	var alerts []string
+	for _, entry := range clone.Logs {
+		if entry.Type == "ALERT" {
+			alerts = append(alerts, entry.Message)
+		}
+	}
+	clone.Logs, clone.ChatMessages, clone.LastAction = nil, nil, last
+	if after, _ := json.Marshal(clone); string(after) != string(before) {
+		return ""
+	}
+	if len(alerts) == 0 {
+		return "La acción no tuvo efecto en la partida"
+	}
+	return strings.Join(alerts, "; ")
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+	"github.com/gabriel3312cl/finances-game/backend/internal/valuation"
+)
+
+// botTraits are the knobs of the heuristic strategy, derived from a bot's personality so that
+// each profile plays its own way without an LLM
+type botTraits struct {
+	cashReserve  int     // Cash kept after buying a property
+	groupReserve int     // Cash kept after buying a property that helps complete or block a group
+	buildReserve int     // Cash kept after buying a building
+	bidFraction  float64 // Most paid in an auction, as a fraction of what the property is worth
+	tradeChance  float64 // Chance of proposing a trade at the end of a turn
+	tradePremium float64 // Most paid over the list price in a cash-only offer, as a fraction of it
+	acceptRatio  float64 // Value received over value given that a trade needs to be accepted
+}
+
+// traitsFor maps a personality to its traits: risk tolerance lowers the reserves, aggression
+// raises bids and building, and negotiation skill makes trades more frequent and harder to win
+func traitsFor(profile domain.BotProfile) botTraits {
+	reserve := float64(valuation.LiquidityReserve) * (1.3 - profile.RiskTolerance)
+	return botTraits{
+		cashReserve:  int(reserve),
+		groupReserve: int(reserve * (1 - profile.Aggression)),
+		buildReserve: int(reserve * (1.5 - profile.Aggression)),
+		bidFraction:  0.75 + 0.5*profile.Aggression,
+		tradeChance:  0.1 + 0.5*profile.NegotiationSkill,
+		tradePremium: 0.1 + 0.5*profile.Aggression,
+		acceptRatio:  0.8 + 0.3*profile.NegotiationSkill,
+	}
+}
+
+// wantsToBuy decides on the unowned property a bot landed on: it buys when the cash left covers
+// its reserve, or the smaller group reserve if the property helps with a group
+func (t botTraits) wantsToBuy(game *domain.GameState, bot *domain.PlayerState, tile domain.Tile) bool {
+	left := bot.Balance - tile.Price
+	if left < 0 {
+		return false
+	}
+	if left >= t.cashReserve {
+		return true
+	}
+	v := valuation.Property(game, bot.UserID, tile.PropertyID)
+	return v != nil && v.GroupBonus > 0 && left >= t.groupReserve
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"math/rand"
+	"strconv"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/cards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func (s *GameService) handleDrawCard(game *domain.GameState, userID string) {
+	if game.CurrentTurnID != userID {
+		return
+	}
+	player := s.getPlayer(game, userID)
+	if player == nil {
+		return
+	}
+
+	// Identify Tile Type
+	deckType := game.Board[player.Position].Type
+	var typeName string
+	switch deckType {
+	case cards.DeckChance:
+		typeName = "Fortuna"
+	case cards.DeckCommunity:
+		typeName = "Arca Comunal"
+	default:
+		return // Not a card tile
+	}
+
+	card := s.drawCard(game, deckType)
+	if card == nil {
+		s.addLog(game, "Error: Deck empty", "ALERT")
+		return
+	}
+	game.DrawnCard = card
+	s.addLog(game, player.Name+" sacó una tarjeta de "+typeName, "ACTION")
+
+	effect, err := cards.Parse(card.Effect)
+	if err != nil {
+		// Invalid rows are left out of the decks when they are loaded
+		s.addLog(game, "Tarjeta inválida: "+err.Error(), "ALERT")
+	}
+	for i := range effect {
+		clause := &effect[i]
+		if clause.Condition != nil && !clause.Condition.Holds(game, player) {
+			continue
+		}
+		s.applyCardClause(game, player, card, clause)
+	}
+
+	game.LastAction = "Tarjeta: " + card.Description
+	s.broadcastGameState(game)
+}
+
+// gameCards returns the deck chosen in a game's settings as it is now
+func (s *GameService) gameCards(game *domain.GameState) []domain.Card {
+	deck, ok := s.cardDecks[game.Settings.CardDeckID]
+	if !ok || game.Settings.CardDeckID == 0 {
+		deck = s.cardDecks[s.defaultCardDeck]
+	}
+	return deck
+}
+
+// cardDeck returns the cards of a type in the deck the game plays with. Games keep the deck
+// they started with; edits made since only apply to new games.
+func (s *GameService) cardDeck(game *domain.GameState, deckType string) []domain.Card {
+	deck := game.Cards
+	if deck == nil {
+		deck = s.gameCards(game)
+	}
+	var out []domain.Card
+	for _, c := range deck {
+		if c.Type == deckType {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// drawCard takes the top card of the game's deck, shuffling the whole deck again once it runs out
+func (s *GameService) drawCard(game *domain.GameState, deckType string) *domain.Card {
+	deck := s.cardDeck(game, deckType)
+	if len(deck) == 0 {
+		return nil
+	}
+	if game.CardDecks == nil {
+		game.CardDecks = make(map[string][]int)
+	}
+	for {
+		if len(game.CardDecks[deckType]) == 0 {
+			game.CardDecks[deckType] = cards.Shuffle(deck)
+		}
+		id := game.CardDecks[deckType][0]
+		game.CardDecks[deckType] = game.CardDecks[deckType][1:]
+		for i := range deck {
+			if deck[i].ID == id {
+				card := deck[i]
+				return &card
+			}
+		}
+		// The card was removed since the deck was shuffled: draw the next one
+	}
+}
+
+// applyCardClause carries out one clause of a card's effect for the player who drew it
+func (s *GameService) applyCardClause(game *domain.GameState, player *domain.PlayerState, card *domain.Card, c *cards.Clause) {
+	switch c.Action {
+	case cards.ActionKeep:
+		// Kept until used with USE_ITEM, spent automatically or traded
+		giveItem(player, c.Item, 1)
+		s.addLog(game, player.Name+" guardó '"+domain.Items[c.Item].Name+"' en su inventario", "INFO")
+	case cards.ActionCollect:
+		if c.Target == cards.TargetBank {
+			s.bankPay(game, player, c.Amount, domain.FlowCard, card.Title)
+			s.addLog(game, "¡Ganó $"+strconv.Itoa(c.Amount)+"!", "SUCCESS")
+			return
+		}
+		others := cardCounterparties(game, player, c.Target)
+		total := 0
+		for _, p := range others {
+			// Players can only pay what they have
+			paid := min(c.Amount, max(p.Balance, 0))
+			s.transfer(game, p.UserID, player.UserID, paid, domain.FlowCard, card.Title)
+			total += paid
+		}
+		if len(others) > 0 {
+			s.addLog(game, "Cobró $"+strconv.Itoa(c.Amount)+" a "+playerNames(others)+" (total $"+strconv.Itoa(total)+")", "SUCCESS")
+		}
+	case cards.ActionPay:
+		if c.Target == cards.TargetBank {
+			s.collectFine(game, player, c.Amount, domain.FlowFine, card.Title)
+			s.addLog(game, "Pagó $"+strconv.Itoa(c.Amount), "ALERT")
+			return
+		}
+		others := cardCounterparties(game, player, c.Target)
+		for _, p := range others {
+			s.transfer(game, player.UserID, p.UserID, c.Amount, domain.FlowCard, card.Title)
+		}
+		if len(others) > 0 {
+			s.addLog(game, "Pagó $"+strconv.Itoa(c.Amount)+" a "+playerNames(others), "ALERT")
+		}
+	case cards.ActionRepair:
+		total := c.RepairCost(game, player.UserID)
+		s.collectFine(game, player, total, domain.FlowFine, card.Title)
+		s.addLog(game, "Reparaciones: Pagó $"+strconv.Itoa(total), "ALERT")
+		s.settleInsuranceClaim(game, player, domain.InsuranceRepair, total)
+	case cards.ActionMove:
+		s.moveByCard(game, player, c)
+	}
+}
+
+// cardCounterparties returns the players on the other side of a collect or pay clause
+func cardCounterparties(game *domain.GameState, player *domain.PlayerState, target string) []*domain.PlayerState {
+	var others []*domain.PlayerState
+	for _, p := range game.Players {
+		if p.IsActive && p.UserID != player.UserID {
+			others = append(others, p)
+		}
+	}
+
+	switch target {
+	case cards.TargetEach:
+		return others
+	case cards.TargetRichest, cards.TargetPoorest:
+		var pick *domain.PlayerState
+		for _, p := range others {
+			if pick == nil || (target == cards.TargetRichest && p.Balance > pick.Balance) || (target == cards.TargetPoorest && p.Balance < pick.Balance) {
+				pick = p
+			}
+		}
+		if pick != nil {
+			return []*domain.PlayerState{pick}
+		}
+	}
+	return nil
+}
+
+func playerNames(players []*domain.PlayerState) string {
+	if len(players) > 1 {
+		return "cada jugador"
+	}
+	return players[0].Name
+}
+
+// moveByCard moves the player to a card's destination. Advancing to or past GO completes a lap,
+// and arriving at someone else's property charges its rent under the card's rent rule.
+func (s *GameService) moveByCard(game *domain.GameState, player *domain.PlayerState, c *cards.Clause) {
+	n := len(game.Board)
+	if n == 0 {
+		return
+	}
+	from, to := player.Position, -1
+	switch c.Destination {
+	case cards.DestJail:
+		s.sendToJail(game, player)
+		s.addLog(game, player.Name+" fue enviado a la Cárcel", "ALERT")
+		return
+	case cards.DestGo, cards.DestGoBonus:
+		to = goPosition(game)
+	case cards.DestRelative:
+		to = ((from+c.Steps)%n + n) % n
+	case cards.DestNearest:
+		to = cards.FindNext(game.Board, from, c.TileType)
+	case cards.DestRandom:
+		if avenues := cards.Avenues(game.Board); len(avenues) > 0 {
+			to = avenues[rand.Intn(len(avenues))]
+		}
+	case cards.DestLast:
+		to = cards.LastProperty(game.Board)
+	case cards.DestProperty:
+		to = cards.FindProperty(game.Board, c.Property)
+	}
+	if to < 0 {
+		s.addLog(game, "El destino de la tarjeta no está en el tablero", "ALERT")
+		return
+	}
+
+	player.Position = to
+	s.trackTileVisit(game, player, to)
+	tile := &game.Board[to]
+
+	if c.Destination == cards.DestRelative && c.Steps < 0 {
+		s.addLog(game, player.Name+" retrocedió "+strconv.Itoa(-c.Steps)+" espacios hasta "+tile.Name, "ACTION")
+	} else {
+		msg := player.Name + " avanzó hasta " + tile.Name
+		if steps := (to-from+n-1)%n + 1; passesGo(game, from, steps) {
+			salary := 200
+			if c.Destination == cards.DestGoBonus {
+				salary = 500
+			}
+			msg += "." + s.passGo(game, player, salary)
+		}
+		s.addLog(game, msg, "ACTION")
+	}
+
+	s.chargeCardRent(game, player, tile, c)
+}
+
+// chargeCardRent charges the rent of the tile a card moved the player to, if another player owns it
+func (s *GameService) chargeCardRent(game *domain.GameState, player *domain.PlayerState, tile *domain.Tile, c *cards.Clause) {
+	if tile.Price == 0 || tile.IsMortgaged {
+		return
+	}
+	ownerID, owned := game.PropertyOwnership[tile.PropertyID]
+	if !owned || ownerID == player.UserID {
+		return
+	}
+	owner := s.getPlayer(game, ownerID)
+	if owner == nil {
+		return
+	}
+
+	var rent int
+	switch c.Rent {
+	case cards.RentDice:
+		rent = (rand.Intn(6) + rand.Intn(6) + 2) * c.Multiplier
+	case cards.RentMultiplied:
+		rent = s.calculateRent(game, tile, game.Dice[0]+game.Dice[1]) * c.Multiplier
+	default:
+		rent = s.calculateRent(game, tile, game.Dice[0]+game.Dice[1])
+	}
+
+	paid, waiver := s.payRent(game, player, owner, tile, rent)
+	if waiver != "" {
+		s.addLog(game, player.Name+" no paga renta en "+tile.Name+": "+waiver, "INFO")
+		return
+	}
+	s.addLog(game, player.Name+" pagó $"+strconv.Itoa(paid)+" de renta a "+owner.Name+" por "+tile.Name, "SUCCESS")
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/cards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// ErrInvalidCard wraps the reasons a card or deck is rejected by the admin API
+var ErrInvalidCard = errors.New("tarjeta inválida")
+
+// CardPreview is how the admin API shows a card's effect before it is saved
+type CardPreview struct {
+	Valid   bool         `json:"valid"`
+	Error   string       `json:"error,omitempty"`
+	Clauses cards.Effect `json:"clauses,omitempty"`
+	Summary []string     `json:"summary,omitempty"` // One line per clause
+}
+
+// PreviewCard parses and validates a card against the current board without saving it
+func (s *GameService) PreviewCard(card domain.Card) *CardPreview {
+	s.mu.RLock()
+	board := s.initializeBoard()
+	s.mu.RUnlock()
+
+	preview := &CardPreview{}
+	if err := cards.Validate(card, board); err != nil {
+		preview.Error = err.Error()
+		return preview
+	}
+	effect, _ := cards.Parse(card.Effect)
+	preview.Valid = true
+	preview.Clauses = effect
+	for _, c := range effect {
+		preview.Summary = append(preview.Summary, cards.Describe(c))
+	}
+	return preview
+}
+
+// ListCardDecks returns every deck; the first one is the default
+func (s *GameService) ListCardDecks() ([]domain.CardDeck, error) {
+	decks, err := s.gameRepo.ListCardDecks()
+	if err != nil {
+		return nil, err
+	}
+	if len(decks) > 0 {
+		decks[0].IsDefault = true
+	}
+	return decks, nil
+}
+
+// CreateCardDeck adds an empty deck
+func (s *GameService) CreateCardDeck(deck domain.CardDeck) (*domain.CardDeck, error) {
+	deck.Name = strings.TrimSpace(deck.Name)
+	if deck.Name == "" || len(deck.Name) > 100 {
+		return nil, fmt.Errorf("%w: el nombre del mazo debe tener entre 1 y 100 caracteres", ErrInvalidCard)
+	}
+	if err := s.gameRepo.CreateCardDeck(&deck); err != nil {
+		return nil, err
+	}
+	deck.CardCount, deck.IsDefault = 0, false
+	return &deck, nil
+}
+
+// ListDeckCards returns every card of a deck, disabled ones included
+func (s *GameService) ListDeckCards(deckID int) ([]domain.Card, error) {
+	return s.gameRepo.ListCards(deckID, true)
+}
+
+// CreateCard validates and adds an enabled card to a deck
+func (s *GameService) CreateCard(card domain.Card) (*domain.Card, error) {
+	card.ID = 0
+	card.Enabled = true
+	if err := s.validateCardRow(card); err != nil {
+		return nil, err
+	}
+	if err := s.gameRepo.CreateCard(&card); err != nil {
+		return nil, err
+	}
+	s.loadCards()
+	return &card, nil
+}
+
+// UpdateCard applies the fields present in the patch to a card, e.g. {"enabled": false} to
+// disable it or {"effect": "..."} to change its effect
+func (s *GameService) UpdateCard(id int, patch json.RawMessage) (*domain.Card, error) {
+	card, err := s.gameRepo.GetCard(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, card); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCard, err)
+	}
+	card.ID = id
+	if err := s.validateCardRow(*card); err != nil {
+		return nil, err
+	}
+	if err := s.gameRepo.UpdateCard(card); err != nil {
+		return nil, err
+	}
+	s.loadCards()
+	return card, nil
+}
+
+// validateCardRow checks a card before it is stored: its deck exists, it has a text and its
+// effect is valid on the board
+func (s *GameService) validateCardRow(card domain.Card) error {
+	decks, err := s.gameRepo.ListCardDecks()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, d := range decks {
+		found = found || d.ID == card.DeckID
+	}
+	if !found {
+		return fmt.Errorf("%w: mazo desconocido", ErrInvalidCard)
+	}
+	if strings.TrimSpace(card.Description) == "" || len(card.Title) > 100 {
+		return fmt.Errorf("%w: la tarjeta necesita una descripción y un título de hasta 100 caracteres", ErrInvalidCard)
+	}
+	s.mu.RLock()
+	board := s.initializeBoard()
+	s.mu.RUnlock()
+	if err := cards.Validate(card, board); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCard, err)
+	}
+	return nil
+}
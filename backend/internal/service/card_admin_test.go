@@ -0,0 +1,32 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func TestCardDeck_EditsOnlyReachNewGames(t *testing.T) {
+	sim, running := simulatedLobby()
+	s := sim.rules
+	old := domain.Card{ID: 1, Type: "CHANCE", Title: "Vieja", Description: "Vieja", Effect: "collect:50", Enabled: true}
+	s.cardDecks = map[int][]domain.Card{1: {old}}
+	s.defaultCardDeck = 1
+	fresh := cloneGame(running)
+	s.handleStartGame(running, "BOT_A", nil)
+
+	// An admin edits the deck, as loadCards would leave it
+	edited := old
+	edited.Title, edited.Effect = "Nueva", "pay:50"
+	added := domain.Card{ID: 2, Type: "CHANCE", Title: "Otra", Description: "Otra", Effect: "collect:10", Enabled: true}
+	s.cardDecks = map[int][]domain.Card{1: {edited, added}}
+
+	if deck := s.cardDeck(running, "CHANCE"); !slices.Equal(deck, []domain.Card{old}) {
+		t.Errorf("the running game picked up the edit: %+v", deck)
+	}
+	s.handleStartGame(fresh, "BOT_A", nil)
+	if deck := s.cardDeck(fresh, "CHANCE"); !slices.Equal(deck, []domain.Card{edited, added}) {
+		t.Errorf("a new game didn't get the edited deck: %+v", deck)
+	}
+}
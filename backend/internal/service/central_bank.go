@@ -0,0 +1,122 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// initEconomy starts the macroeconomic model at the neutral rate and the base price level
+func (s *GameService) initEconomy(game *domain.GameState) {
+	game.Economy = &domain.Economy{
+		BaseRate:    domain.NeutralBaseRate,
+		PriceIndex:  1.0,
+		MoneySupply: s.moneySupply(game),
+	}
+	s.addLog(game, "🏦 Banco Central activo. Tasa base inicial: "+strconv.Itoa(domain.NeutralBaseRate)+"%", "INFO")
+}
+
+// moneySupply is the cash and savings held by active players plus their outstanding loans.
+// Moving cash into savings leaves it unchanged.
+func (s *GameService) moneySupply(game *domain.GameState) int {
+	total := 0
+	for _, p := range game.Players {
+		if !p.IsActive {
+			continue
+		}
+		total += p.Balance + p.Savings + p.Loan
+	}
+	return total
+}
+
+// updateEconomy derives the round's inflation from money supply growth, applies it to
+// board prices and lets the central bank move the base rate with a Taylor-style rule
+func (s *GameService) updateEconomy(game *domain.GameState) {
+	eco := game.Economy
+	supply := s.moneySupply(game)
+
+	inflation := 0.0
+	if eco.MoneySupply > 0 {
+		// Half of the money growth passes through to prices
+		inflation = float64(supply-eco.MoneySupply) / float64(eco.MoneySupply) * 100 / 2
+	}
+	inflation = math.Max(-domain.MaxInflationPerTurn, math.Min(domain.MaxInflationPerTurn, inflation))
+
+	eco.Inflation = inflation
+	eco.MoneySupply = supply
+	eco.PriceIndex *= 1 + inflation/100
+	if eco.PriceIndex < 0.5 {
+		eco.PriceIndex = 0.5
+	}
+	s.applyPriceIndex(game)
+
+	// Taylor rule: react 1.5x to the deviation from the inflation target
+	target := domain.NeutralBaseRate + int(math.Round(1.5*(inflation-domain.InflationTarget)))
+	if target < domain.MinBaseRate {
+		target = domain.MinBaseRate
+	}
+	if target > domain.MaxBaseRate {
+		target = domain.MaxBaseRate
+	}
+
+	if target != eco.BaseRate {
+		verb := "subió"
+		if target < eco.BaseRate {
+			verb = "bajó"
+		}
+		eco.BaseRate = target
+		eco.LastRateRound = game.Round
+		s.addLog(game, fmt.Sprintf("🏦 El Banco Central %s la tasa base a %d%% (inflación de la ronda: %.1f%%)", verb, target, inflation), "ALERT")
+	}
+}
+
+// applyPriceIndex rescales the board's prices, rents and building costs from the catalog values
+func (s *GameService) applyPriceIndex(game *domain.GameState) {
+	index := game.Economy.PriceIndex
+	scale := func(v int) int { return int(math.Round(float64(v) * index)) }
+
+	for i := range game.Board {
+		tile := &game.Board[i]
+		prop, ok := s.catalogProperty(game, tile.PropertyID)
+		if !ok {
+			continue
+		}
+		tile.Price = scale(prop.Price)
+		tile.Rent = scale(prop.RentBase)
+		tile.RentBase = scale(prop.RentBase)
+		tile.RentColorGroup = scale(prop.RentColorGroup)
+		tile.Rent1House = scale(prop.Rent1House)
+		tile.Rent2House = scale(prop.Rent2House)
+		tile.Rent3House = scale(prop.Rent3House)
+		tile.Rent4House = scale(prop.Rent4House)
+		tile.RentHotel = scale(prop.RentHotel)
+		tile.HouseCost = scale(prop.HouseCost)
+		tile.HotelCost = scale(prop.HotelCost)
+		tile.MortgageValue = scale(prop.MortgageValue)
+		tile.UnmortgageValue = scale(prop.UnmortgageValue)
+	}
+}
+
+// inflate scales a fixed amount (e.g. railroad rents) by the game's price level
+func inflate(game *domain.GameState, amount int) int {
+	if game.Economy == nil {
+		return amount
+	}
+	return int(math.Round(float64(amount) * game.Economy.PriceIndex))
+}
+
+// loanRate returns the interest rate charged to a player: the credit score spread
+// over the central bank's base rate when the economy model is on, the fixed table otherwise
+func (s *GameService) loanRate(game *domain.GameState, score int) int {
+	rate := s.getInterestRate(score)
+	if game.Economy == nil {
+		return rate
+	}
+	rate += game.Economy.BaseRate - domain.NeutralBaseRate
+	if rate < 1 {
+		rate = 1
+	}
+	return rate
+}
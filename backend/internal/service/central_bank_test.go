@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func TestCentralBank_RatesFollowMoneyGrowth(t *testing.T) {
+	catalog := &domain.Property{ID: "P1", Name: "Uno", Type: "PROPERTY", Price: 100, RentBase: 10, Rent1House: 50,
+		HouseCost: 50, HotelCost: 50, MortgageValue: 50, UnmortgageValue: 55}
+	def := &domain.BoardDefinition{Tiles: []domain.BoardTile{{Type: "PROPERTY", Property: catalog}}}
+	game := &domain.GameState{
+		Status: domain.GameStatusActive,
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 1000, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 1000, IsActive: true},
+		},
+		Board: []domain.Tile{{PropertyID: "P1", Name: "Uno", Type: "PROPERTY", Price: 100, RentBase: 10, HouseCost: 50, MortgageValue: 50}},
+	}
+	s := &GameService{boards: map[int]*domain.BoardDefinition{0: def}}
+	s.initTreasury(game)
+	s.initLedger(game)
+	s.initEconomy(game)
+	eco := game.Economy
+
+	// 20% more money: half passes to prices, capped at 5% a round, and the rate jumps
+	s.bankPay(game, game.Players[0], 400, domain.FlowSalary, "Salida")
+	s.updateEconomy(game)
+	if eco.Inflation != domain.MaxInflationPerTurn || eco.PriceIndex != 1.05 {
+		t.Errorf("inflation %.2f%%, price index %.3f", eco.Inflation, eco.PriceIndex)
+	}
+	if want := domain.NeutralBaseRate + 6; eco.BaseRate != want { // 1.5 x (5% - 1% target)
+		t.Errorf("base rate %d%%, want %d%%", eco.BaseRate, want)
+	}
+	tile := game.Board[0]
+	if tile.Price != 105 || tile.RentBase != 11 || tile.Rent1House != 53 || tile.HouseCost != 53 || tile.MortgageValue != 53 || tile.UnmortgageValue != 58 {
+		t.Errorf("prices not rescaled from the catalog: %+v", tile)
+	}
+
+	// Stable money undershoots the target: the rate goes below neutral. Savings are still money.
+	s.handleDepositSavings(game, "b", []byte(`{"amount": 500}`))
+	s.updateEconomy(game)
+	if game.Players[1].Savings != 500 {
+		t.Fatal("the deposit didn't go through")
+	}
+	if eco.Inflation != 0 || eco.PriceIndex != 1.05 || eco.BaseRate >= domain.NeutralBaseRate {
+		t.Errorf("flat round: inflation %.2f%%, index %.3f, rate %d%%", eco.Inflation, eco.PriceIndex, eco.BaseRate)
+	}
+
+	// A collapse can't push prices below half or the rate below the floor
+	eco.PriceIndex = 0.51
+	s.bankCollect(game, game.Players[1], 1000, domain.FlowFine, "Multa")
+	s.updateEconomy(game)
+	if eco.PriceIndex != 0.5 || eco.BaseRate != domain.MinBaseRate || game.Board[0].Price != 50 {
+		t.Errorf("after the collapse: index %.3f, rate %d%%, price %d", eco.PriceIndex, eco.BaseRate, game.Board[0].Price)
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// validateContractTerm checks a contract clause against the trade that proposes it
+func (s *GameService) validateContractTerm(game *domain.GameState, trade *domain.TradeOffer, term domain.ContractTerm) error {
+	if term.GrantorID == term.BeneficiaryID || !isTradeParticipant(trade, term.GrantorID) || !isTradeParticipant(trade, term.BeneficiaryID) {
+		return errors.New("el contrato debe ser entre dos participantes del intercambio")
+	}
+	if term.Rounds < 0 || term.Rounds > domain.MaxContractRounds || term.Landings < 0 {
+		return errors.New("duración de contrato inválida")
+	}
+
+	switch term.Type {
+	case domain.ContractRentImmunity:
+		found := false
+		for _, t := range game.Board {
+			if t.GroupIdentifier != "" && t.GroupIdentifier == term.GroupID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New("grupo desconocido: " + term.GroupID)
+		}
+		if term.Landings == 0 && term.Rounds == 0 {
+			return errors.New("la exención de renta necesita un límite de visitas o rondas")
+		}
+	case domain.ContractRevenueShare:
+		if s.propertyTile(game, term.PropertyID) == nil {
+			return errors.New("propiedad desconocida: " + term.PropertyID)
+		}
+		if term.Percent <= 0 || term.Percent > 100 {
+			return errors.New("el porcentaje de renta debe estar entre 1 y 100")
+		}
+		if term.Rounds == 0 {
+			return errors.New("la participación en la renta necesita una duración en rondas")
+		}
+	case domain.ContractPayOnGo:
+		if term.Amount <= 0 {
+			return errors.New("el pago al pasar por la salida debe ser positivo")
+		}
+	default:
+		return errors.New("tipo de contrato desconocido: " + term.Type)
+	}
+	return nil
+}
+
+// signContracts puts the contract terms of an executed trade in force
+func (s *GameService) signContracts(game *domain.GameState, trade *domain.TradeOffer) {
+	for _, term := range trade.Terms {
+		grantor := s.getPlayer(game, term.GrantorID)
+		beneficiary := s.getPlayer(game, term.BeneficiaryID)
+		if grantor == nil || beneficiary == nil {
+			continue
+		}
+		game.NextContractID++
+		contract := &domain.Contract{
+			ID:                fmt.Sprintf("CT-%d", game.NextContractID),
+			TradeID:           trade.ID,
+			Type:              term.Type,
+			GrantorID:         grantor.UserID,
+			GrantorName:       grantor.Name,
+			BeneficiaryID:     beneficiary.UserID,
+			BeneficiaryName:   beneficiary.Name,
+			GroupID:           term.GroupID,
+			PropertyID:        term.PropertyID,
+			Percent:           term.Percent,
+			Amount:            term.Amount,
+			RemainingLandings: term.Landings,
+		}
+		if term.Rounds > 0 {
+			contract.ExpiresRound = game.Round + term.Rounds
+		}
+		game.Contracts = append(game.Contracts, contract)
+		s.addLog(game, "📜 Contrato firmado: "+describeContract(game, contract), "INFO")
+	}
+}
+
+// describeContract renders a contract for the game log
+func describeContract(game *domain.GameState, c *domain.Contract) string {
+	groupName, propertyName := c.GroupID, c.PropertyID
+	for _, t := range game.Board {
+		if c.GroupID != "" && t.GroupIdentifier == c.GroupID && t.GroupName != "" {
+			groupName = t.GroupName
+		}
+		if c.PropertyID != "" && t.PropertyID == c.PropertyID {
+			propertyName = t.Name
+		}
+	}
+
+	var msg string
+	switch c.Type {
+	case domain.ContractRentImmunity:
+		msg = c.BeneficiaryName + " no paga renta en el grupo " + groupName + " de " + c.GrantorName
+		if c.RemainingLandings > 0 {
+			msg += " (" + strconv.Itoa(c.RemainingLandings) + " visitas)"
+		}
+	case domain.ContractRevenueShare:
+		msg = c.BeneficiaryName + " recibe el " + strconv.Itoa(c.Percent) + "% de la renta de " + propertyName + " de " + c.GrantorName
+	case domain.ContractPayOnGo:
+		msg = c.GrantorName + " pagará $" + strconv.Itoa(c.Amount) + " a " + c.BeneficiaryName + " al pasar por la SALIDA"
+	}
+	if c.ExpiresRound > 0 {
+		msg += " hasta la ronda " + strconv.Itoa(c.ExpiresRound)
+	}
+	return msg
+}
+
+// removeContract takes a contract out of force
+func removeContract(game *domain.GameState, contract *domain.Contract) {
+	for i, c := range game.Contracts {
+		if c == contract {
+			game.Contracts = append(game.Contracts[:i], game.Contracts[i+1:]...)
+			return
+		}
+	}
+}
+
+// rentImmunity returns the contract exempting the tenant from the owner's rent on a tile, if any
+func rentImmunity(game *domain.GameState, tile *domain.Tile, tenantID, ownerID string) *domain.Contract {
+	if tile.GroupIdentifier == "" {
+		return nil
+	}
+	for _, c := range game.Contracts {
+		if c.Type == domain.ContractRentImmunity && c.GrantorID == ownerID && c.BeneficiaryID == tenantID && c.GroupID == tile.GroupIdentifier {
+			return c
+		}
+	}
+	return nil
+}
+
+// useRentImmunity records a waived rent and ends the contract when no landings remain
+func (s *GameService) useRentImmunity(game *domain.GameState, contract *domain.Contract, rent int) {
+	contract.TotalValue += rent
+	if contract.RemainingLandings == 0 {
+		return
+	}
+	contract.RemainingLandings--
+	if contract.RemainingLandings == 0 {
+		removeContract(game, contract)
+		s.addLog(game, "📜 Se agotó la exención de renta de "+contract.BeneficiaryName, "INFO")
+	}
+}
+
+// shareRentRevenue pays the revenue shares of a property out of the rent its owner just collected
+func (s *GameService) shareRentRevenue(game *domain.GameState, tile *domain.Tile, rent int, owner *domain.PlayerState) {
+	for _, c := range game.Contracts {
+		// The contract lapses in practice while the grantor doesn't own the property
+		if c.Type != domain.ContractRevenueShare || c.PropertyID != tile.PropertyID || c.GrantorID != owner.UserID {
+			continue
+		}
+		share := rent * c.Percent / 100
+		if share <= 0 {
+			continue
+		}
+		s.transfer(game, owner.UserID, c.BeneficiaryID, share, domain.ReasonContract, c.ID)
+		c.TotalValue += share
+		s.addLog(game, "📜 "+c.BeneficiaryName+" recibe $"+strconv.Itoa(share)+" de la renta de "+tile.Name+" por contrato", "INFO")
+	}
+}
+
+// settleGoContracts pays the PAY_ON_GO contracts of a player passing GO.
+// Returns the fragment to append to the pass-GO message.
+func (s *GameService) settleGoContracts(game *domain.GameState, player *domain.PlayerState) string {
+	msg := ""
+	for _, c := range append([]*domain.Contract(nil), game.Contracts...) {
+		if c.Type != domain.ContractPayOnGo || c.GrantorID != player.UserID {
+			continue
+		}
+		// Owed like rent: a short balance goes negative and the player must cover it
+		s.transfer(game, player.UserID, c.BeneficiaryID, c.Amount, domain.ReasonContract, c.ID)
+		c.TotalValue += c.Amount
+		removeContract(game, c)
+		msg += " Pagó $" + strconv.Itoa(c.Amount) + " a " + c.BeneficiaryName + " por contrato."
+		s.warnDebt(game, player, c.BeneficiaryName)
+	}
+	return msg
+}
+
+// expireContracts ends the contracts whose duration is over, called when the table round advances
+func (s *GameService) expireContracts(game *domain.GameState) {
+	active := game.Contracts[:0]
+	for _, c := range game.Contracts {
+		if c.ExpiresRound > 0 && game.Round >= c.ExpiresRound {
+			s.addLog(game, "📜 Venció el contrato "+c.ID+": "+describeContract(game, c), "INFO")
+			continue
+		}
+		active = append(active, c)
+	}
+	game.Contracts = active
+}
+
+// cancelContractsOf voids every contract involving a player, e.g. when they go bankrupt
+func (s *GameService) cancelContractsOf(game *domain.GameState, userID string) {
+	active := game.Contracts[:0]
+	for _, c := range game.Contracts {
+		if c.GrantorID != userID && c.BeneficiaryID != userID {
+			active = append(active, c)
+		}
+	}
+	game.Contracts = active
+}
@@ -7,42 +7,44 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/gabriel3312cl/finances-game/backend/internal/boards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/cards"
 	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
 	"github.com/gabriel3312cl/finances-game/backend/internal/handler/websocket"
 	"github.com/gabriel3312cl/finances-game/backend/internal/repository/postgres"
 )
 
 type GameService struct {
-	games          map[string]*domain.GameState
-	properties     map[string]domain.Property // Cache
-	boardLayout    map[int]string             // Position -> PropertyID (UUID)
-	db             *sql.DB
-	gameRepo       *postgres.GameRepository
-	userRepo       *postgres.UserRepository // Add UserRepo
-	mu             sync.RWMutex
-	hub            *websocket.Hub
-	active         map[string]bool
-	chanceCards    []domain.Card
-	communityCards []domain.Card
-	botService     *BotService // Dependency injection
+	games           map[string]*domain.GameState
+	boards          map[int]*domain.BoardDefinition // Board ID -> definition, 0 = classic
+	db              *sql.DB
+	gameRepo        *postgres.GameRepository
+	userRepo        *postgres.UserRepository // Add UserRepo
+	mu              sync.RWMutex
+	hub             *websocket.Hub
+	active          map[string]bool
+	cardDecks       map[int][]domain.Card // Deck ID -> enabled cards
+	defaultCardDeck int
+	botService      *BotService     // Dependency injection
+	dutchWatch      map[string]bool // Game ID -> a goroutine follows its Dutch auction
 }
 
 func NewGameService(hub *websocket.Hub, db *sql.DB, gameRepo *postgres.GameRepository, userRepo *postgres.UserRepository) *GameService {
 	s := &GameService{
-		games:       make(map[string]*domain.GameState),
-		properties:  make(map[string]domain.Property),
-		boardLayout: make(map[int]string),
-		db:          db,
-		gameRepo:    gameRepo,
-		userRepo:    userRepo,
-		hub:         hub,
-		active:      make(map[string]bool),
+		games:    make(map[string]*domain.GameState),
+		boards:   make(map[int]*domain.BoardDefinition),
+		db:       db,
+		gameRepo: gameRepo,
+		userRepo: userRepo,
+		hub:      hub,
+		active:   make(map[string]bool),
 	}
 	s.loadPropertiesAndLayout()
 	s.loadActiveGames() // Load from DB
@@ -179,7 +181,10 @@ func (s *GameService) addLogWithMeta(game *domain.GameState, message string, log
 	game.Logs = append(game.Logs, entry)
 	game.LastAction = message // Keep legacy field for now
 
-	// Persist Log Immediately
+	// Persist Log Immediately (there's no repository in unit tests)
+	if s.gameRepo == nil {
+		return
+	}
 	go func() {
 		if err := s.gameRepo.SaveLog(game.GameID, entry); err != nil {
 			log.Printf("Error saving log: %v", err)
@@ -193,6 +198,11 @@ func (s *GameService) saveGame(game *domain.GameState) {
 		game.Logs = game.Logs[len(game.Logs)-100:]
 	}
 
+	// Simulated games aren't stored
+	if s.gameRepo == nil {
+		return
+	}
+
 	// Helper to save async so we don't block
 	go func(g *domain.GameState) {
 		if err := s.gameRepo.Save(g); err != nil {
@@ -201,17 +211,19 @@ func (s *GameService) saveGame(game *domain.GameState) {
 	}(game)
 }
 
+// GetGamesByUser returns the games a user plays in, as their players see them
 func (s *GameService) GetGamesByUser(userID string) []*domain.GameState {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	var result []*domain.GameState
 	for _, g := range s.games {
-		for _, p := range g.Players {
-			if p.UserID == userID {
-				result = append(result, g)
-				break
-			}
+		if s.getPlayer(g, userID) == nil {
+			continue
+		}
+		// Copy so the caller can encode it after the lock is released
+		if view := cloneGame(publicView(g)); view != nil {
+			result = append(result, view)
 		}
 	}
 	return result
@@ -293,7 +305,7 @@ func (s *GameService) loadPropertiesAndLayout() {
 	// 1. Load Properties
 	// 1. Load Properties
 	rows, err := s.db.Query(`SELECT 
-		id, name, type, group_id, group_name, group_color, price, 
+		id, COALESCE(slug, ''), name, type, group_id, group_name, group_color, price, 
 		rent_base, rent_color_group, rent_1_house, rent_2_house, rent_3_house, rent_4_house, rent_hotel,
 		rent_rule, house_cost, hotel_cost, mortgage_value, unmortgage_value
 		FROM properties`)
@@ -303,6 +315,7 @@ func (s *GameService) loadPropertiesAndLayout() {
 	}
 	defer rows.Close()
 
+	properties := make(map[string]domain.Property)
 	count := 0
 	for rows.Next() {
 		var p domain.Property
@@ -311,7 +324,7 @@ func (s *GameService) loadPropertiesAndLayout() {
 		var rentRule sql.NullString
 
 		if err := rows.Scan(
-			&p.ID, &p.Name, &p.Type, &groupID, &groupName, &groupColor, &p.Price,
+			&p.ID, &p.Slug, &p.Name, &p.Type, &groupID, &groupName, &groupColor, &p.Price,
 			&rentBase, &rentColorGroup, &r1, &r2, &r3, &r4, &rHotel,
 			&rentRule, &hCost, &hotCost, &mort, &unmort,
 		); err != nil {
@@ -366,7 +379,7 @@ func (s *GameService) loadPropertiesAndLayout() {
 			p.RentRule = rentRule.String
 		}
 
-		s.properties[p.ID] = p
+		properties[p.ID] = p
 		count++
 	}
 	log.Printf("Loaded %d properties", count)
@@ -378,56 +391,60 @@ func (s *GameService) loadPropertiesAndLayout() {
 		return
 	}
 
+	// We map Position -> PropertyID (UUID), or the tile type for special tiles (e.g. "CORNER", "TAX")
+	boardLayout := make(map[int]string)
 	for pos, item := range layout {
-		// We map Position -> PropertyID (UUID)
-		// If PropertyID is empty (e.g. Corner), we might store Type?
-		// For getLayoutID compatibility, let's store PropertyID if exists, else "TYPE"
 		if item.PropertyID != "" {
-			s.boardLayout[pos] = item.PropertyID
+			boardLayout[pos] = item.PropertyID
 		} else {
-			s.boardLayout[pos] = item.Type // e.g. "CORNER", "TAX"
+			boardLayout[pos] = item.Type
 		}
 	}
-	log.Printf("Loaded %d layout items", len(s.boardLayout))
+	log.Printf("Loaded %d layout items", len(boardLayout))
+	s.boards[0] = classicBoard(properties, boardLayout)
+	for _, p := range boards.Validate(s.boards[0]).Problems {
+		log.Printf("Classic board problem, %s", p)
+	}
 
+	s.loadBoards()
 	s.loadCards()
 }
 
+// loadCards caches the enabled cards of every deck. Every row is checked against the board: a
+// card that doesn't parse never reaches a deck. Runs at startup and after every change made
+// through the admin API, so edits reach games on their next draw without a restart.
 func (s *GameService) loadCards() {
-	// Reset decks
-	s.chanceCards = []domain.Card{}
-	s.communityCards = []domain.Card{}
-
-	// Load All Cards
-	rows, err := s.db.Query("SELECT id, type, title, description, effect FROM game_cards")
+	all, err := s.gameRepo.ListCards(0, false)
 	if err != nil {
 		log.Printf("Error loading game cards: %v", err)
 		return
 	}
-	defer rows.Close()
+	decks, err := s.gameRepo.ListCardDecks()
+	if err != nil {
+		log.Printf("Error loading card decks: %v", err)
+		return
+	}
 
-	for rows.Next() {
-		var c domain.Card
-		// Use sql.NullString for title if nullable? I defined it as VARCHAR(100) (nullable by default).
-		// But Scan expects non-nil.
-		// I'll scan into string, assuming I seeded all with titles.
-		// Or helper scan.
-		var title sql.NullString
-		if err := rows.Scan(&c.ID, &c.Type, &title, &c.Description, &c.Effect); err != nil {
-			log.Printf("Error scanning card: %v", err)
+	s.mu.RLock()
+	board := s.initializeBoard()
+	s.mu.RUnlock()
+	byDeck := make(map[int][]domain.Card)
+	for _, c := range all {
+		if err := cards.Validate(c, board); err != nil {
+			log.Printf("Skipping invalid card %d (%s): %v", c.ID, c.Effect, err)
 			continue
 		}
-		if title.Valid {
-			c.Title = title.String
-		}
-
-		if c.Type == "CHANCE" {
-			s.chanceCards = append(s.chanceCards, c)
-		} else if c.Type == "COMMUNITY" {
-			s.communityCards = append(s.communityCards, c)
-		}
+		byDeck[c.DeckID] = append(byDeck[c.DeckID], c)
+	}
+	defaultDeck := 0
+	if len(decks) > 0 {
+		defaultDeck = decks[0].ID
 	}
-	log.Printf("Loaded %d Chance and %d Community cards", len(s.chanceCards), len(s.communityCards))
+
+	s.mu.Lock()
+	s.cardDecks, s.defaultCardDeck = byDeck, defaultDeck
+	s.mu.Unlock()
+	log.Printf("Loaded %d cards in %d decks", len(all), len(byDeck))
 }
 
 func (s *GameService) JoinGame(code string, user *domain.User) (*domain.GameState, error) {
@@ -556,12 +573,26 @@ func (s *GameService) HandleAction(gameID string, userID string, message []byte)
 		return
 	}
 
-	switch action.Action {
+	// Queries answer the player who asked and change nothing
+	if action.Action == "GET_LEGAL_ACTIONS" {
+		s.sendLegalActions(game, userID)
+		return
+	}
+
+	s.guardAction(game, action.Action, func() {
+		s.dispatch(game, userID, action.Action, action.Payload)
+	})
+}
+
+// dispatch runs a player's action through its handler, the same way for humans and bots. It
+// reports false for unknown actions. Callers hold s.mu.
+func (s *GameService) dispatch(game *domain.GameState, userID string, action string, payload json.RawMessage) bool {
+	switch action {
 	case "JOIN_GAME":
 		// Just broadcast state to ensure client has it
 		s.broadcastGameState(game)
 	case "START_GAME":
-		s.handleStartGame(game, userID, action.Payload)
+		s.handleStartGame(game, userID, payload)
 	case "ROLL_ORDER":
 		s.handleRollOrder(game, userID)
 	case "ROLL_DICE":
@@ -569,50 +600,75 @@ func (s *GameService) HandleAction(gameID string, userID string, message []byte)
 	case "END_TURN":
 		s.handleEndTurn(game, userID)
 	case "START_AUCTION":
-		s.handleStartAuction(game, userID, action.Payload)
+		s.handleStartAuction(game, userID, payload)
 	case "BID":
-		s.handleBid(game, userID, action.Payload)
+		s.handleBid(game, userID, payload)
+	case "PASS_AUCTION":
+		s.handlePassAuction(game, userID)
 	case "BUY_PROPERTY":
-		s.handleBuyProperty(game, userID, action.Payload)
+		s.handleBuyProperty(game, userID, payload)
 	case "TAKE_LOAN":
-		s.handleTakeLoan(game, userID, action.Payload)
+		s.handleTakeLoan(game, userID, payload)
 	case "PAY_LOAN":
-		s.handlePayLoan(game, userID, action.Payload)
+		s.handlePayLoan(game, userID, payload)
 	case "INITIATE_TRADE":
-		s.handleInitiateTrade(game, userID, action.Payload)
+		s.handleInitiateTrade(game, userID, payload)
 	case "ACCEPT_TRADE":
-		s.handleAcceptTrade(game, userID, action.Payload)
+		s.handleAcceptTrade(game, userID, payload)
 	case "REJECT_TRADE":
-		s.handleRejectTrade(game, userID, action.Payload)
+		s.handleRejectTrade(game, userID, payload)
+	case "COUNTER_TRADE":
+		s.handleCounterTrade(game, userID, payload)
 	case "FINALIZE_AUCTION":
 		s.handleFinalizeAuction(game)
 	case "DRAW_CARD":
 		s.handleDrawCard(game, userID)
 	case "PAY_RENT":
-		s.handlePayRent(game, userID, action.Payload)
+		s.handlePayRent(game, userID, payload)
 	case "COLLECT_RENT": // New Manual Action
 		s.handleCollectRent(game, userID)
 	case "BUY_BUILDING":
-		s.handleBuyBuilding(game, userID, action.Payload)
+		s.handleBuyBuilding(game, userID, payload)
 	case "SELL_BUILDING":
-		s.handleSellBuilding(game, userID, action.Payload)
+		s.handleSellBuilding(game, userID, payload)
 	case "MORTGAGE_PROPERTY":
-		s.handleMortgageProperty(game, userID, action.Payload)
+		s.handleMortgageProperty(game, userID, payload)
 	case "UNMORTGAGE_PROPERTY":
-		s.handleUnmortgageProperty(game, userID, action.Payload)
+		s.handleUnmortgageProperty(game, userID, payload)
 	case "SELL_PROPERTY":
-		s.handleSellProperty(game, userID, action.Payload)
+		s.handleSellProperty(game, userID, payload)
 	case "ADD_BOT":
-		s.handleAddBot(game, userID, action.Payload)
+		s.handleAddBot(game, userID, payload)
 	case "DECLARE_BANKRUPTCY":
 		s.handleDeclareBankruptcy(game, userID)
 	case "PAY_BAIL":
 		s.handlePayBail(game, userID)
+	case "USE_ITEM":
+		s.handleUseItem(game, userID, payload)
+	case "USE_JAIL_FREE_CARD":
+		s.useItem(game, userID, domain.ItemJailFree)
 	case "UPDATE_PLAYER_CONFIG":
-		s.handleUpdatePlayerConfig(game, userID, action.Payload)
+		s.handleUpdatePlayerConfig(game, userID, payload)
 	case "SEND_CHAT":
-		s.handleSendChat(game, userID, action.Payload)
+		s.handleSendChat(game, userID, payload)
+	case "BUY_SHARES":
+		s.handleBuyShares(game, userID, payload)
+	case "SELL_SHARES":
+		s.handleSellShares(game, userID, payload)
+	case "CANCEL_SHARE_ORDER":
+		s.handleCancelShareOrder(game, userID, payload)
+	case "BUY_INSURANCE":
+		s.handleBuyInsurance(game, userID, payload)
+	case "DEPOSIT_SAVINGS":
+		s.handleDepositSavings(game, userID, payload)
+	case "WITHDRAW_SAVINGS":
+		s.handleWithdrawSavings(game, userID, payload)
+	case "PAY_INCOME_TAX":
+		s.handlePayIncomeTax(game, userID, payload)
+	default:
+		return false
 	}
+	return true
 }
 
 func (s *GameService) handleUpdatePlayerConfig(game *domain.GameState, userID string, payload json.RawMessage) {
@@ -744,339 +800,33 @@ func (s *GameService) handleAddBot(game *domain.GameState, userID string, payloa
 	s.broadcastGameState(game)
 }
 
-func (s *GameService) handleFinalizeAuction(game *domain.GameState) {
-	if game.ActiveAuction == nil || !game.ActiveAuction.IsActive {
-		return
-	}
-	// Check if time is actually up
-	now := time.Now()
-	lastBid := time.Unix(game.ActiveAuction.LastBidTime, 0)
-
-	// Auto-Win: If > 5s passed since last bid and we have a bidder
-	if game.ActiveAuction.BidderID != "" && now.Sub(lastBid) > 5*time.Second {
-		s.endAuction(game)
-		return
-	}
-
-	if now.After(game.ActiveAuction.EndTime) {
-		s.endAuction(game)
-	}
-}
-
-func (s *GameService) handleStartAuction(game *domain.GameState, userID string, payload json.RawMessage) {
-	// Only current player can start auction? Or anyone for unowned property?
-	// For now, assume current player triggers it instead of buying.
-
-	var req struct {
-		PropertyID string `json:"property_id"`
-	}
-	if err := json.Unmarshal(payload, &req); err != nil {
-		return
-	}
-
-	// Validation: Verify property is not owned (omitted for speed, trusting frontend/rules for now)
-
-	game.ActiveAuction = &domain.AuctionState{
-		PropertyID:    req.PropertyID,
-		HighestBid:    10, // Starting bid?
-		BidderID:      "",
-		BidderName:    "No bids",
-		EndTime:       time.Now().Add(30 * time.Second), // 30s auction
-		LastBidTime:   time.Now().Unix(),
-		IsActive:      true,
-		PassedPlayers: make(map[string]bool),
-	}
-	game.LastAction = "Subasta iniciada por " + req.PropertyID
-	s.addLog(game, "Subasta iniciada por "+req.PropertyID, "INFO")
-
-	s.broadcastGameState(game)
-}
-
-func (s *GameService) handleBid(game *domain.GameState, userID string, payload json.RawMessage) {
-	if game.ActiveAuction == nil || !game.ActiveAuction.IsActive {
-		return
-	}
-
-	// Check expiry
-	if time.Now().After(game.ActiveAuction.EndTime) {
-		s.endAuction(game)
-		return
-	}
-
-	var req struct {
-		Amount int `json:"amount"`
-	}
-	if err := json.Unmarshal(payload, &req); err != nil {
-		return
-	}
-
-	// Validate Bid
-	if req.Amount <= game.ActiveAuction.HighestBid {
-		return
-	}
-
-	// Find Bidder Name
-	var bidderName string
-	for _, p := range game.Players {
-		if p.UserID == userID {
-			if int64(p.Balance) < int64(req.Amount) {
-				return // Insufficient funds
-			}
-			bidderName = p.Name
-			break
-		}
-	}
-
-	game.ActiveAuction.HighestBid = req.Amount
-	game.ActiveAuction.BidderID = userID
-	game.ActiveAuction.BidderName = bidderName
-	game.ActiveAuction.LastBidTime = time.Now().Unix()
-
-	// Anti-sniping: extend if < 10s left
-	timeLeft := time.Until(game.ActiveAuction.EndTime)
-	if timeLeft < 10*time.Second {
-		game.ActiveAuction.EndTime = time.Now().Add(10 * time.Second) // Extend time
-	}
-
-	s.addLog(game, bidderName+" ha pujado $"+strconv.Itoa(req.Amount), "INFO")
-	s.broadcastGameState(game)
-}
-
-func (s *GameService) handlePassAuction(game *domain.GameState, userID string) {
-	if game.ActiveAuction == nil || !game.ActiveAuction.IsActive {
-		return
-	}
-	if game.ActiveAuction.PassedPlayers == nil {
-		game.ActiveAuction.PassedPlayers = make(map[string]bool)
-	}
-	game.ActiveAuction.PassedPlayers[userID] = true
-	s.addLog(game, "Jugador ha pasado en la subasta.", "INFO")
-	// Check if only 1 player remaining? Not implementing complex logic yet.
-	s.broadcastGameState(game)
-}
-
-func (s *GameService) handleDrawCard(game *domain.GameState, userID string) {
-	if game.CurrentTurnID != userID {
-		return
-	}
-
-	// Find Player
-	var player *domain.PlayerState
-	for _, p := range game.Players {
-		if p.UserID == userID {
-			player = p
-			break
-		}
-	}
-	if player == nil {
-		return
-	}
-
-	// Identify Tile Type
-	layoutID := s.getLayoutID(player.Position)
-	var deck []domain.Card
-	var typeName string
-
-	if layoutID == "CHANCE" {
-		deck = s.chanceCards
-		typeName = "Fortuna"
-	} else if layoutID == "COMMUNITY" {
-		deck = s.communityCards
-		typeName = "Arca Comunal"
-	} else {
-		return // Not a card tile
-	}
-
-	if len(deck) == 0 {
-		s.addLog(game, "Error: Deck empty", "ALERT")
-		return
-	}
-
-	// Draw Random
-	card := deck[rand.Intn(len(deck))]
-	game.DrawnCard = &card
-	s.addLog(game, player.Name+" sacó una tarjeta de "+typeName, "ACTION")
-
-	// Execute Effect logic
-	importStr := card.Effect
-	// Format: "cmd:arg" or "cmd:arg1:arg2"
-
-	if importStr == "jail_free" {
-		// Add "Get Out of Jail Free" to Inventory
-		// Simplified: just log it (Need inventory system update which is game_players JSONB)
-		// For MVP, just give cash value? No, user wants persistence.
-		// Since I haven't implemented Item Inventory fully, I will just give $50 as "Sale Value"
-		player.Balance += 50
-		s.addLog(game, "Tarjeta 'Sal de la Cárcel'. Se vendió por $50 (Inventario no disponible)", "INFO")
-	} else if len(importStr) > 8 && importStr[:8] == "collect:" {
-		val, _ := strconv.Atoi(importStr[8:])
-		player.Balance += val
-		s.addLog(game, "¡Ganó $"+strconv.Itoa(val)+"!", "SUCCESS")
-	} else if len(importStr) > 4 && importStr[:4] == "pay:" {
-		val, _ := strconv.Atoi(importStr[4:])
-		player.Balance -= val
-		s.addLog(game, "Pagó $"+strconv.Itoa(val), "ALERT")
-	} else if len(importStr) > 12 && importStr[:12] == "collect_all:" {
-		amount, _ := strconv.Atoi(importStr[12:])
-		total := 0
-		for _, p := range game.Players {
-			if p.UserID != userID && p.IsActive {
-				p.Balance -= amount
-				total += amount
-			}
-		}
-		player.Balance += total
-		s.addLog(game, "Cobró $"+strconv.Itoa(amount)+" a cada jugador", "SUCCESS")
-	} else if len(importStr) > 8 && importStr[:8] == "pay_all:" {
-		amount, _ := strconv.Atoi(importStr[8:])
-		for _, p := range game.Players {
-			if p.UserID != userID && p.IsActive {
-				p.Balance += amount
-				player.Balance -= amount
-			}
-		}
-		s.addLog(game, "Pagó $"+strconv.Itoa(amount)+" a cada jugador", "ALERT")
-	} else if len(importStr) > 5 && importStr[:5] == "move:" {
-		target := importStr[5:]
-
-		if target == "GO" {
-			player.Position = 0
-			player.Balance += 200 // Standard pass go
-			s.trackTileVisit(game, player, 0)
-			s.addLog(game, "Avanzó hasta la SALIDA", "action")
-		} else if target == "GO_BONUS" {
-			player.Position = 0
-			player.Balance += 500 // User requested 500
-			s.trackTileVisit(game, player, 0)
-			s.addLog(game, "Avanzó a Salida (Bonus $500)", "SUCCESS")
-		} else if target == "JAIL" {
-			player.InJail = true
-			player.Position = 16
-			s.trackTileVisit(game, player, 16)
-			s.addLog(game, "Fue enviado a la Cárcel", "ALERT")
-		} else if target == "-3" {
-			player.Position = (player.Position - 3 + domain.BoardSize) % domain.BoardSize
-			s.trackTileVisit(game, player, player.Position)
-			s.addLog(game, "Retrocedió 3 espacios", "action")
-		} else if target == "nearest_railroad" {
-			// Find next railroad
-			for i := 1; i < domain.BoardSize; i++ {
-				pos := (player.Position + i) % domain.BoardSize
-				if s.getLayoutType(pos) == "RAILROAD" {
-					player.Position = pos
-					s.trackTileVisit(game, player, pos)
-					s.addLog(game, "Avanzó al ferrocarril más cercano", "action")
-					// TODO: Logic for paying double?
-					break
-				}
-			}
-		} else if target == "nearest_utility" {
-			for i := 1; i < domain.BoardSize; i++ {
-				pos := (player.Position + i) % domain.BoardSize
-				if s.getLayoutType(pos) == "UTILITY" {
-					player.Position = pos
-					s.trackTileVisit(game, player, pos)
-					s.addLog(game, "Avanzó a la utilidad más cercana", "action")
-					break
-				}
-			}
-		} else if target == "random_property" {
-			// simplified: move next property
-			player.Position = (player.Position + 1) % domain.BoardSize
-			s.trackTileVisit(game, player, player.Position)
-			s.addLog(game, "Avanzó (Aleatorio)", "action")
-		} else if target == "last_property" {
-			player.Position = domain.BoardSize - 1 // Last tile?
-			s.trackTileVisit(game, player, player.Position)
-			s.addLog(game, "Avanzó a la última casilla", "action")
-		} else if target == "av-ossa" {
-			// Need precise index lookup. For now, approximate or skip if logic not ready.
-			// I don't have map String->Index loaded in memory efficiently (only Index->ID).
-			// Will check boardLayout values if PropertyID matches?
-			// Too complex for this snippet. Just logging support needed.
-			s.addLog(game, "Movimiento a "+target+" no implementado exacto", "INFO")
-		}
-	} else if len(importStr) > 7 && importStr[:7] == "repair:" {
-		// repair:25:100
-		// parts := parseRepair(importStr[7:]) // need helper or inline
-		// Helper logic inline:
-		// Assume "25:100"
-		// Count houses/hotels
-		costHouse := 25
-		costHotel := 100
-		// parse...
-		// Calc total
-		total := 0
-		// Need to iterate properties owned by player.
-		// game.PropertyOwnership[uuid] == userID.
-		// Then check s.properties[uuid] or where houses stored (game_properties table).
-		// Wait, Houses stored in `game_properties` DB table, not loaded in `GameState` fully?
-		// `GameState` has `Board []Tile` which has `BuildingCount`.
-		// Iterate Board.
-		for _, t := range game.Board {
-			if t.OwnerID != nil && *t.OwnerID == userID {
-				if t.BuildingCount == 5 {
-					total += costHotel
-				} else {
-					total += t.BuildingCount * costHouse
-				}
-			}
-		}
-		player.Balance -= total
-		s.addLog(game, "Reparaciones: Pagó $"+strconv.Itoa(total), "ALERT")
-	}
-
-	game.LastAction = "Tarjeta: " + card.Description
-	s.broadcastGameState(game)
-}
-
-func (s *GameService) getLayoutType(pos int) string {
-	// Helper to lookup type
-	// implementation:
-	if id, ok := s.boardLayout[pos]; ok {
-		if prop, exists := s.properties[id]; exists {
-			return prop.Type
-		}
-		return id // e.g. "CHANCE"
-	}
-	return ""
+// payRent moves the rent of a tile from the tenant to its owner, with everything that follows
+// from it (dividends, revenue shares, insurance claims), unless a contract or a shield waives it.
+// Returns the rent paid, which a rent doubler may have changed, or why it was waived.
+func (s *GameService) payRent(game *domain.GameState, tenant, owner *domain.PlayerState, tile *domain.Tile, rent int) (int, string) {
+	if contract := rentImmunity(game, tile, tenant.UserID, owner.UserID); contract != nil {
+		s.useRentImmunity(game, contract, rent)
+		return 0, "Exento de renta por contrato con " + owner.Name
+	}
+	rent, shielded := s.rentItems(game, tenant, owner, rent)
+	if shielded {
+		return 0, "Protegido por un Escudo"
+	}
+	s.transfer(game, tenant.UserID, owner.UserID, rent, domain.ReasonRent, tile.Name)
+	s.payDividends(game, tile, rent, owner)
+	s.shareRentRevenue(game, tile, rent, owner)
+	s.settleInsuranceClaim(game, tenant, domain.InsuranceRent, rent)
+	s.warnDebt(game, tenant, owner.Name)
+	return rent, ""
 }
 
-func (s *GameService) endAuction(game *domain.GameState) {
-	if game.ActiveAuction == nil || !game.ActiveAuction.IsActive {
+// warnDebt alerts a player left with a negative balance after paying a creditor. Until they
+// raise the money (selling, mortgaging) or declare bankruptcy, nothing else is open to them.
+func (s *GameService) warnDebt(game *domain.GameState, player *domain.PlayerState, creditor string) {
+	if player.Balance >= 0 {
 		return
 	}
-
-	winnerID := game.ActiveAuction.BidderID
-	amount := int64(game.ActiveAuction.HighestBid)
-
-	if winnerID != "" {
-		// Deduct Balance & Assign Property
-		for _, p := range game.Players {
-			if p.UserID == winnerID {
-				p.Balance -= int(amount)
-				break
-			}
-		}
-		// Assign Property
-		game.PropertyOwnership[game.ActiveAuction.PropertyID] = winnerID
-
-		// Update Board
-		for i := range game.Board {
-			if game.Board[i].PropertyID == game.ActiveAuction.PropertyID {
-				game.Board[i].OwnerID = &winnerID
-				break
-			}
-		}
-
-		game.LastAction = "¡Subasta finalizada! Ganador: " + game.ActiveAuction.BidderName
-		s.addLog(game, "¡Subasta finalizada! Ganador: "+game.ActiveAuction.BidderName+" por $"+strconv.Itoa(int(amount)), "SUCCESS")
-	} else {
-		game.LastAction = "¡Subasta finalizada! Sin ofertas."
-	}
-
-	game.ActiveAuction = nil
-	s.broadcastGameState(game)
+	s.addLog(game, "⚠️ "+player.Name+" quedó debiendo $"+strconv.Itoa(-player.Balance)+" tras pagar a "+creditor+": debe vender, hipotecar o declararse en bancarrota", "ALERT")
 }
 
 func (s *GameService) handleBuyProperty(game *domain.GameState, userID string, payload json.RawMessage) {
@@ -1088,7 +838,7 @@ func (s *GameService) handleBuyProperty(game *domain.GameState, userID string, p
 		return
 	}
 
-	prop, exists := s.properties[req.PropertyID]
+	prop, exists := s.catalogProperty(game, req.PropertyID)
 	if !exists {
 		return
 	}
@@ -1111,8 +861,9 @@ func (s *GameService) handleBuyProperty(game *domain.GameState, userID string, p
 	}
 
 	// 2. Execute Purchase
-	player.Balance -= int(prop.Price)
+	s.bankCollect(game, player, prop.Price, domain.FlowProperty, prop.Name)
 	game.PropertyOwnership[req.PropertyID] = userID
+	setCostBasis(game, req.PropertyID, prop.Price)
 	game.LastAction = player.Name + " compró " + prop.Name + " por $" + strconv.Itoa(prop.Price)
 	s.addLog(game, player.Name+" compró "+prop.Name+" por $"+strconv.Itoa(prop.Price), "SUCCESS")
 
@@ -1127,132 +878,6 @@ func (s *GameService) handleBuyProperty(game *domain.GameState, userID string, p
 	s.broadcastGameState(game)
 }
 
-func (s *GameService) handleInitiateTrade(game *domain.GameState, userID string, payload json.RawMessage) {
-	// Only one trade at a time to keep it simple
-	if game.ActiveTrade != nil {
-		return
-	}
-
-	var req domain.TradeOffer
-	if err := json.Unmarshal(payload, &req); err != nil {
-		return
-	}
-
-	// Basic Validation
-	if req.TargetID == "" || req.TargetID == userID {
-		return
-	}
-
-	// Fill names
-	var offererName, targetName string
-	for _, p := range game.Players {
-		if p.UserID == userID {
-			offererName = p.Name
-		}
-		if p.UserID == req.TargetID {
-			targetName = p.Name
-		}
-	}
-
-	game.ActiveTrade = &domain.TradeOffer{
-		ID:                strconv.Itoa(int(time.Now().Unix())),
-		OffererID:         userID,
-		OffererName:       offererName,
-		TargetID:          req.TargetID,
-		TargetName:        targetName,
-		OfferPropeties:    req.OfferPropeties,
-		OfferCash:         req.OfferCash,
-		RequestProperties: req.RequestProperties,
-		RequestCash:       req.RequestCash,
-		Status:            "PENDING",
-	}
-
-	game.LastAction = offererName + " propuso un intercambio a " + targetName
-	s.broadcastGameState(game)
-}
-
-func (s *GameService) handleAcceptTrade(game *domain.GameState, userID string, payload json.RawMessage) {
-	if game.ActiveTrade == nil || game.ActiveTrade.TargetID != userID {
-		return
-	}
-
-	trade := game.ActiveTrade
-
-	// Execute Swap
-	// 1. Money Transfer
-	var offerer, target *domain.PlayerState
-	for _, p := range game.Players {
-		if p.UserID == trade.OffererID {
-			offerer = p
-		}
-		if p.UserID == trade.TargetID {
-			target = p
-		}
-	}
-
-	if offerer == nil || target == nil {
-		game.ActiveTrade = nil
-		return
-	}
-
-	// Verify Cash funds
-	if offerer.Balance < int(trade.OfferCash) || target.Balance < int(trade.RequestCash) {
-		game.LastAction = "Intercambio fallido: Fondos insuficientes"
-		game.ActiveTrade = nil
-		s.broadcastGameState(game)
-		return
-	}
-
-	// Transfer Cash
-	offerer.Balance -= int(trade.OfferCash)
-	target.Balance += int(trade.OfferCash)
-
-	target.Balance -= int(trade.RequestCash)
-	offerer.Balance += int(trade.RequestCash)
-
-	// 2. Property Transfer
-	// TODO: Verify ownership again for safety? Assuming UI is correct for now.
-	for _, propID := range trade.OfferPropeties {
-		if game.PropertyOwnership[propID] == trade.OffererID {
-			game.PropertyOwnership[propID] = trade.TargetID
-		}
-	}
-	for _, propID := range trade.RequestProperties {
-		if game.PropertyOwnership[propID] == trade.TargetID {
-			game.PropertyOwnership[propID] = trade.OffererID
-		}
-	}
-
-	msg := "Intercambio realizado entre " + trade.OffererName + " y " + trade.TargetName
-	game.LastAction = msg
-	s.addLog(game, msg, "SUCCESS")
-	game.ActiveTrade = nil
-	s.broadcastGameState(game)
-}
-
-func (s *GameService) handleRejectTrade(game *domain.GameState, userID string, payload json.RawMessage) {
-	if game.ActiveTrade == nil {
-		return
-	}
-	// Only Target or Offerer can cancel/reject
-	if userID != game.ActiveTrade.TargetID && userID != game.ActiveTrade.OffererID {
-		return
-	}
-
-	actorName := "Jugador"
-	for _, p := range game.Players {
-		if p.UserID == userID {
-			actorName = p.Name
-			break
-		}
-	}
-	msg := actorName + " rechazó/canceló el intercambio"
-	game.LastAction = msg
-	s.addLog(game, msg, "ALERT")
-	game.ActiveTrade = nil
-	s.broadcastGameState(game)
-}
-
 func (s *GameService) handleTakeLoan(game *domain.GameState, userID string, payload json.RawMessage) {
 	var req struct {
 		Amount int `json:"amount"`
@@ -1277,12 +902,12 @@ func (s *GameService) handleTakeLoan(game *domain.GameState, userID string, payl
 				return
 			}
 
-			p.Balance += req.Amount
+			s.bankPay(game, p, req.Amount, domain.FlowLoan, "")
 			p.Loan += req.Amount
 			p.Credit.LoansTaken++
 			p.Credit.LastLoanRound = p.Credit.CurrentRound
 
-			interestRate := s.getInterestRate(p.Credit.Score)
+			interestRate := s.loanRate(game, p.Credit.Score)
 			s.addLog(game, p.Name+" tomó préstamo de $"+strconv.Itoa(req.Amount)+" (Tasa: "+strconv.Itoa(interestRate)+"%)", "SUCCESS")
 			break
 		}
@@ -1314,7 +939,7 @@ func (s *GameService) handlePayLoan(game *domain.GameState, userID string, paylo
 				return // Insufficient funds
 			}
 
-			p.Balance -= req.Amount
+			s.bankCollect(game, p, req.Amount, domain.FlowLoanRepayment, "")
 			p.Loan -= req.Amount
 
 			// Check if paid "on time" (within 3 rounds of taking loan)
@@ -1387,7 +1012,7 @@ func (s *GameService) handleRollDice(game *domain.GameState, userID string) {
 			currentPlayer.JailTurns++
 			if currentPlayer.JailTurns >= 3 {
 				// Must pay bail after 3 failed attempts
-				currentPlayer.Balance -= 50
+				s.collectFine(game, currentPlayer, 50, domain.FlowBail, "")
 				currentPlayer.InJail = false
 				currentPlayer.JailTurns = 0
 				s.addLog(game, currentPlayer.Name+" pagó $50 de fianza obligatoria tras 3 turnos en cárcel", "ALERT")
@@ -1404,7 +1029,7 @@ func (s *GameService) handleRollDice(game *domain.GameState, userID string) {
 
 	// 4. Move Player (only if not stuck in jail)
 	oldPos := currentPlayer.Position
-	newPos := (currentPlayer.Position + total) % 64
+	newPos := (currentPlayer.Position + total) % len(game.Board)
 	currentPlayer.Position = newPos
 
 	// Track Visits
@@ -1412,64 +1037,13 @@ func (s *GameService) handleRollDice(game *domain.GameState, userID string) {
 
 	// Check Pass Go
 	var passGoMsg string
-	if newPos < oldPos { // If new position is less than old position, it means player passed GO
-		currentPlayer.Balance += 200
-		passGoMsg = " ¡Pasó por la SALIDA! Cobra $200."
-
-		// ===== CREDIT SYSTEM: Interest Accrual =====
-		s.initCreditProfile(currentPlayer)
-		currentPlayer.Credit.CurrentRound++
-
-		if currentPlayer.Loan > 0 {
-			currentPlayer.Credit.RoundsInDebt++
-			rate := s.getInterestRate(currentPlayer.Credit.Score)
-
-			// Add delinquency penalty after 3 rounds
-			if currentPlayer.Credit.RoundsInDebt > 3 {
-				rate += 10 // Extra 10% penalty
-			}
-
-			interest := (currentPlayer.Loan * rate) / 100
-
-			// ===== AUTOMATIC AMORTIZATION: 15% of principal =====
-			minimumPayment := currentPlayer.Loan * 15 / 100
-			if minimumPayment < 50 {
-				minimumPayment = 50 // Minimum $50 payment
-			}
-			if minimumPayment > currentPlayer.Loan {
-				minimumPayment = currentPlayer.Loan
-			}
-
-			totalDeduction := interest + minimumPayment
-
-			// Try to pay from balance (salary already added: +$200)
-			if currentPlayer.Balance >= totalDeduction {
-				currentPlayer.Balance -= totalDeduction
-				currentPlayer.Loan -= minimumPayment
-				passGoMsg += " Cuota: $" + strconv.Itoa(minimumPayment) + " + Int: $" + strconv.Itoa(interest) + "."
-
-				// If fully paid, reward credit
-				if currentPlayer.Loan <= 0 {
-					currentPlayer.Loan = 0
-					currentPlayer.Credit.LoansPaidOnTime++
-					currentPlayer.Credit.RoundsInDebt = 0
-					passGoMsg += " ¡Deuda saldada!"
-				}
-			} else {
-				// Can't afford minimum payment - just pay interest + whatever possible
-				currentPlayer.Balance -= interest
-				currentPlayer.Loan += interest   // Interest still accrues
-				currentPlayer.Credit.Score -= 25 // Penalty for missing minimum
-				passGoMsg += " ⚠️ No alcanzó cuota mínima ($" + strconv.Itoa(minimumPayment) + "). Score -25."
-			}
-
-			s.calculateCreditScore(game, currentPlayer)
-		}
+	if passesGo(game, oldPos, total) {
+		passGoMsg = s.passGo(game, currentPlayer, 200)
 	}
 
-	// ===== BONUS: Landing exactly on GO (position 0) =====
-	if newPos == 0 {
-		currentPlayer.Balance += 500
+	// ===== BONUS: Landing exactly on GO =====
+	if newPos == goPosition(game) {
+		s.bankPay(game, currentPlayer, 500, domain.FlowSalary, "Salida (bonus)")
 		passGoMsg += " ¡BONUS! Cayó en SALIDA: +$500"
 		s.addLog(game, currentPlayer.Name+" cayó exactamente en SALIDA y recibe $500 de bonus!", "SUCCESS")
 	}
@@ -1482,10 +1056,8 @@ func (s *GameService) handleRollDice(game *domain.GameState, userID string) {
 	}
 
 	// Check Tile
-	propID := s.getLayoutID(newPos)
-	prop, isProperty := s.properties[propID]
-
-	tileID := propID // For consistency with old code
+	tileID := game.Board[newPos].PropertyID
+	prop, isProperty := s.catalogProperty(game, tileID)
 
 	if isProperty {
 		ownerID, isOwned := game.PropertyOwnership[tileID]
@@ -1509,11 +1081,13 @@ func (s *GameService) handleRollDice(game *domain.GameState, userID string) {
 						rent := s.calculateRent(game, tile, total)
 
 						// AUTOMATIC RENT: Deduct from player, add to owner immediately
-						currentPlayer.Balance -= rent
-						owner.Balance += rent
-
-						desc += ". Cayó en " + prop.Name + ". Pagó renta: $" + strconv.Itoa(rent) + " a " + owner.Name
-						s.addLog(game, currentPlayer.Name+" pagó $"+strconv.Itoa(rent)+" de renta a "+owner.Name+" por "+prop.Name, "SUCCESS")
+						if paid, waiver := s.payRent(game, currentPlayer, owner, tile, rent); waiver != "" {
+							desc += ". Cayó en " + prop.Name + ". " + waiver
+						} else {
+							rent = paid
+							desc += ". Cayó en " + prop.Name + ". Pagó renta: $" + strconv.Itoa(rent) + " a " + owner.Name
+							s.addLog(game, currentPlayer.Name+" pagó $"+strconv.Itoa(rent)+" de renta a "+owner.Name+" por "+prop.Name, "SUCCESS")
+						}
 					}
 				}
 			} else {
@@ -1524,20 +1098,15 @@ func (s *GameService) handleRollDice(game *domain.GameState, userID string) {
 		}
 	} else {
 		// Special Tiles Logic
-		switch newPos {
-		case 5: // Income Tax
-			currentPlayer.Balance -= 200
-			desc += ". Pagó Impuesto sobre la Renta ($200)"
-			s.addLog(game, currentPlayer.Name+" pagó impuesto sobre la renta ($200)", "ALERT")
-		case 62: // Luxury Tax
-			currentPlayer.Balance -= 100
-			desc += ". Pagó Impuesto de Lujo ($100)"
-			s.addLog(game, currentPlayer.Name+" pagó impuesto de lujo ($100)", "ALERT")
-		case 48: // Go To Jail
-			currentPlayer.Position = 16 // Jail
-			currentPlayer.InJail = true
-			currentPlayer.JailTurns = 0
-			s.trackTileVisit(game, currentPlayer, 16)
+		switch game.Board[newPos].Type {
+		case domain.TileIncomeTax:
+			desc += s.handleIncomeTaxTile(game, currentPlayer)
+		case domain.TileLuxuryTax:
+			desc += s.chargeLuxuryTax(game, currentPlayer)
+		case domain.TileFreeParking:
+			desc += s.collectFreeParking(game, currentPlayer)
+		case domain.TileGoToJail:
+			s.sendToJail(game, currentPlayer)
 			desc += ". ¡Vaya a la Cárcel!"
 			s.addLog(game, currentPlayer.Name+" fue enviado a la cárcel", "ALERT")
 			// Auto-end turn when going to jail
@@ -1555,6 +1124,69 @@ func (s *GameService) handleRollDice(game *domain.GameState, userID string) {
 	s.broadcastGameState(game)
 }
 
+// passGo pays the salary to a player passing GO and runs everything that happens once per lap:
+// loan interest and amortization, savings interest, insurance expiry and GO contracts.
+// Returns the message to append to the move description.
+func (s *GameService) passGo(game *domain.GameState, player *domain.PlayerState, salary int) string {
+	s.bankPay(game, player, salary, domain.FlowSalary, "Salida")
+	passGoMsg := " ¡Pasó por la SALIDA! Cobra $" + strconv.Itoa(salary) + "."
+
+	// ===== CREDIT SYSTEM: Interest Accrual =====
+	s.initCreditProfile(player)
+	player.Credit.CurrentRound++
+	s.expirePolicies(game, player)
+	passGoMsg += s.accrueSavingsInterest(game, player)
+	passGoMsg += s.settleGoContracts(game, player)
+
+	if player.Loan > 0 {
+		player.Credit.RoundsInDebt++
+		rate := s.loanRate(game, player.Credit.Score)
+
+		// Add delinquency penalty after 3 rounds
+		if player.Credit.RoundsInDebt > 3 {
+			rate += 10 // Extra 10% penalty
+		}
+
+		interest := (player.Loan * rate) / 100
+
+		// ===== AUTOMATIC AMORTIZATION: 15% of principal =====
+		minimumPayment := player.Loan * 15 / 100
+		if minimumPayment < 50 {
+			minimumPayment = 50 // Minimum $50 payment
+		}
+		if minimumPayment > player.Loan {
+			minimumPayment = player.Loan
+		}
+
+		totalDeduction := interest + minimumPayment
+
+		// Try to pay from balance (salary already added)
+		if player.Balance >= totalDeduction {
+			s.bankCollect(game, player, interest, domain.FlowInterest, "")
+			s.bankCollect(game, player, minimumPayment, domain.FlowLoanRepayment, "Cuota automática")
+			player.Loan -= minimumPayment
+			passGoMsg += " Cuota: $" + strconv.Itoa(minimumPayment) + " + Int: $" + strconv.Itoa(interest) + "."
+
+			// If fully paid, reward credit
+			if player.Loan <= 0 {
+				player.Loan = 0
+				player.Credit.LoansPaidOnTime++
+				player.Credit.RoundsInDebt = 0
+				passGoMsg += " ¡Deuda saldada!"
+			}
+		} else {
+			// Can't afford minimum payment - just pay interest + whatever possible
+			s.bankCollect(game, player, interest, domain.FlowInterest, "")
+			player.Loan += interest   // Interest still accrues
+			player.Credit.Score -= 25 // Penalty for missing minimum
+			passGoMsg += " ⚠️ No alcanzó cuota mínima ($" + strconv.Itoa(minimumPayment) + "). Score -25."
+		}
+
+		s.calculateCreditScore(game, player)
+	}
+	return passGoMsg
+}
+
 func (s *GameService) handlePayBail(game *domain.GameState, userID string) {
 	// Find player
 	var player *domain.PlayerState
@@ -1582,7 +1214,7 @@ func (s *GameService) handlePayBail(game *domain.GameState, userID string) {
 	}
 
 	// Pay bail and get out of jail
-	player.Balance -= 50
+	s.collectFine(game, player, 50, domain.FlowBail, "")
 	player.InJail = false
 	player.JailTurns = 0
 	s.addLog(game, player.Name+" pagó $50 de fianza y sale de la cárcel!", "SUCCESS")
@@ -1604,6 +1236,9 @@ func (s *GameService) handleDeclareBankruptcy(game *domain.GameState, userID str
 
 	player.IsActive = false
 	s.addLog(game, player.Name+" se ha declarado en BANCARROTA.", "ALERT")
+	s.cancelTradesOf(game, userID)
+	s.cancelContractsOf(game, userID)
+	player.Inventory = nil
 
 	// Reset Assets
 	for i := range game.Board {
@@ -1615,9 +1250,30 @@ func (s *GameService) handleDeclareBankruptcy(game *domain.GameState, userID str
 			// Clear ownership map reference if strictly needed, but board is truth
 			if tile.PropertyID != "" {
 				delete(game.PropertyOwnership, tile.PropertyID)
+				delete(game.CostBasis, tile.PropertyID)
+				if game.Settings.BankAuctions != nil && game.Settings.BankAuctions.Foreclosures {
+					queueBankAuction(game, tile.PropertyID)
+				}
 			}
 		}
 	}
+	s.transfer(game, savingsAccount(userID), domain.AccountBank, player.Savings, domain.FlowBankruptcy, "Ahorros confiscados")
+
+	// Last player standing wins
+	activePlayers := 0
+	for _, p := range game.Players {
+		if p.IsActive {
+			activePlayers++
+		}
+	}
+	if activePlayers <= 1 && game.Status == domain.GameStatusActive {
+		s.finishGame(game)
+		s.broadcastGameState(game)
+		return
+	}
+
+	// Foreclosed properties go under the hammer
+	s.runBankAuctions(game)
 
 	// If it was their turn, pass it
 	if game.CurrentTurnID == userID {
@@ -1633,6 +1289,12 @@ func (s *GameService) handleEndTurn(game *domain.GameState, userID string) {
 	}
 
 	// Rent is now automatic, no need to block for pending rent
+	s.expireTrades(game)
+
+	// An unanswered income tax choice defaults to the flat amount
+	if game.PendingTax != nil && game.PendingTax.PlayerID == userID {
+		s.settlePendingTax(game, domain.IncomeTaxFlat)
+	}
 
 	// Simple Next Turn Logic using TurnOrder if available, else standard order
 	idx := -1
@@ -1676,6 +1338,10 @@ func (s *GameService) handleEndTurn(game *domain.GameState, userID string) {
 				game.CurrentTurnID = nextUID
 				s.addLog(game, "El turno pasa a "+pState.Name, "INFO")
 				found = true
+				// Wrapping around the turn order completes a round
+				if nextIdx <= idx {
+					s.advanceRound(game)
+				}
 				break
 			}
 			attempts++
@@ -1697,6 +1363,18 @@ func (s *GameService) handleEndTurn(game *domain.GameState, userID string) {
 	s.broadcastGameState(game)
 }
 
+// advanceRound is called when the turn order wraps around to its first player
+func (s *GameService) advanceRound(game *domain.GameState) {
+	game.Round++
+	s.chargePropertyTaxes(game)
+	s.expireContracts(game)
+	s.scheduleBankAuction(game)
+	s.runBankAuctions(game)
+	if game.Economy != nil {
+		s.updateEconomy(game)
+	}
+}
+
 func (s *GameService) handleStartGame(game *domain.GameState, userID string, payload json.RawMessage) {
 	// Only host can start
 	if game.Status != domain.GameStatusWaiting {
@@ -1712,16 +1390,41 @@ func (s *GameService) handleStartGame(game *domain.GameState, userID string, pay
 
 	// Parse initial balance from payload (default 1500)
 	var req struct {
-		InitialBalance int `json:"initial_balance"`
+		InitialBalance int                 `json:"initial_balance"`
+		Settings       domain.GameSettings `json:"settings"`
 	}
 	if err := json.Unmarshal(payload, &req); err != nil || req.InitialBalance <= 0 {
 		req.InitialBalance = 1500 // Default
 	}
+	game.Settings = req.Settings
+	if id := game.Settings.CardDeckID; id != 0 && len(s.cardDecks[id]) == 0 {
+		game.Settings.CardDeckID = 0
+		s.addLog(game, "El mazo elegido no tiene cartas; se usa el mazo por defecto", "ALERT")
+	}
+	game.Cards = slices.Clone(s.gameCards(game))
+	if id := game.Settings.BoardID; id != 0 {
+		def, ok := s.boards[id]
+		switch {
+		case !ok:
+			game.Settings.BoardID = 0
+			s.addLog(game, "El tablero elegido no existe; se usa el tablero clásico", "ALERT")
+		case !boards.Validate(def).Valid:
+			game.Settings.BoardID = 0
+			s.addLog(game, "El tablero elegido tiene errores; se usa el tablero clásico", "ALERT")
+		default:
+			game.Board = boards.Build(def)
+			s.addLog(game, "Tablero: "+def.Name+" ("+strconv.Itoa(len(game.Board))+" casillas)", "INFO")
+		}
+	}
 
-	// Apply initial balance to all players
+	// Apply initial balance to all players, who start on GO
+	start := goPosition(game)
 	for _, p := range game.Players {
 		p.Balance = req.InitialBalance
+		p.Position = start
 	}
+	s.initTreasury(game)
+	s.initLedger(game)
 
 	// Transition to ROLLING_ORDER phase
 	game.Status = domain.GameStatusRollingOrder
@@ -1729,6 +1432,14 @@ func (s *GameService) handleStartGame(game *domain.GameState, userID string, pay
 	game.LastAction = "¡Fase de tirada para orden de turnos!"
 	s.addLog(game, "Cada jugador debe tirar los dados para determinar el orden de juego", "INFO")
 	s.addLog(game, "Dinero inicial: $"+strconv.Itoa(req.InitialBalance)+" para cada jugador", "INFO")
+
+	// Optional house rules
+	if game.Settings.StockMarket {
+		s.initStockMarket(game)
+	}
+	if game.Settings.CentralBank {
+		s.initEconomy(game)
+	}
 	s.broadcastGameState(game)
 }
 
@@ -1841,8 +1552,15 @@ func (s *GameService) handleCollectRent(game *domain.GameState, userID string) {
 			// Let's just deduct.
 		}
 
-		target.Balance -= actualPay
-		creditor.Balance += actualPay
+		tile := s.propertyTile(game, game.PendingRent.PropertyID)
+		if tile == nil {
+			s.transfer(game, target.UserID, creditor.UserID, actualPay, domain.ReasonRent, "")
+		} else {
+			s.transfer(game, target.UserID, creditor.UserID, actualPay, domain.ReasonRent, tile.Name)
+			s.payDividends(game, tile, actualPay, creditor)
+			s.shareRentRevenue(game, tile, actualPay, creditor)
+		}
+		s.warnDebt(game, target, creditor.Name)
 
 		s.addLog(game, creditor.Name+" cobró la renta de $"+strconv.Itoa(actualPay)+" a "+target.Name, "SUCCESS")
 	}
@@ -1853,13 +1571,18 @@ func (s *GameService) handleCollectRent(game *domain.GameState, userID string) {
 
 func (s *GameService) broadcastGameState(game *domain.GameState) {
 	s.saveGame(game) // Persist every update
+	if s.hub == nil {
+		return // Simulated game: nobody is watching
+	}
+
+	view := publicView(game)
 
 	data, _ := json.Marshal(struct {
 		Type    string            `json:"type"`
 		Payload *domain.GameState `json:"payload"`
 	}{
 		Type:    "GAME_STATE",
-		Payload: game,
+		Payload: view,
 	})
 
 	s.hub.Broadcast <- &websocket.BroadcastMessage{
@@ -1871,6 +1594,40 @@ func (s *GameService) broadcastGameState(game *domain.GameState) {
 	go s.checkBotTurn(game)
 }
 
+// publicView is a shallow copy of a game as its players may see it, with the sealed bids of
+// the active auction and the cards and draw order of the decks hidden
+func publicView(game *domain.GameState) *domain.GameState {
+	view := *game
+	view.ActiveAuction = publicAuction(game.ActiveAuction)
+	view.CardDecks = nil
+	view.Cards = nil
+	return &view
+}
+
+// sendToUser sends a message to the connections of one player of a game, for answers to their
+// queries
+func (s *GameService) sendToUser(gameID string, userID string, msgType string, payload any) {
+	if s.hub == nil {
+		return
+	}
+	data, err := json.Marshal(struct {
+		Type    string `json:"type"`
+		Payload any    `json:"payload"`
+	}{
+		Type:    msgType,
+		Payload: payload,
+	})
+	if err != nil {
+		log.Printf("Error encoding %s for %s: %v", msgType, userID, err)
+		return
+	}
+	s.hub.Broadcast <- &websocket.BroadcastMessage{
+		GameID:  gameID,
+		UserID:  userID,
+		Payload: data,
+	}
+}
+
 func (s *GameService) checkBotTurn(game *domain.GameState) {
 	if s.botService == nil {
 		return
@@ -1936,14 +1693,42 @@ func (s *GameService) checkBotTurn(game *domain.GameState) {
 
 			// Find a bot that is NOT the current bidder and wants to bid
 			var botToAct *domain.PlayerState
+			format := auctionFormat(g.ActiveAuction)
+			waiting := false
 			for _, p := range g.Players {
-				if p.IsBot && p.UserID != g.ActiveAuction.BidderID {
+				if p.IsBot && p.IsActive && p.UserID != g.ActiveAuction.BidderID {
 					// Check if this bot has already passed on this auction
-					if _, passed := g.ActiveAuction.PassedPlayers[p.UserID]; !passed {
-						botToAct = p
-						break // Only one bot acts per cycle
+					if _, passed := g.ActiveAuction.PassedPlayers[p.UserID]; passed {
+						continue
+					}
+					// Sealed envelopes are handed in once
+					if _, sealed := g.ActiveAuction.SealedBids[p.UserID]; sealed {
+						continue
+					}
+					// On a Dutch clock bots wait until the price is right
+					if format == domain.AuctionDutch && s.botService.auctionDecision(g, p).Action != "BID" {
+						waiting = true
+						continue
+					}
+					botToAct = p
+					break // Only one bot acts per cycle
+				}
+			}
+
+			// Nobody takes the Dutch price yet: close it at the floor or look again after the
+			// next drop
+			if botToAct == nil && waiting {
+				if time.Now().After(g.ActiveAuction.EndTime) {
+					for _, p := range g.Players {
+						if p.IsBot && p.IsActive {
+							s.runBotAction(g, p, &domain.BotAction{Action: "FINALIZE_AUCTION"})
+							break
+						}
 					}
+					return
 				}
+				s.watchDutchAuction(gameID)
+				return
 			}
 
 			if botToAct != nil {
@@ -1972,16 +1757,18 @@ func (s *GameService) checkBotTurn(game *domain.GameState) {
 
 	// Rent is now automatic - removed PendingRent sections (1.6 and 1.65)
 
-	// 1.7 Check for ACTIVE TRADE where target is a bot
+	// 1.7 Check for open trades where target is a bot
 	s.mu.RLock()
-	hasActiveTrade := game.ActiveTrade != nil
 	var targetBot *domain.PlayerState
-	if hasActiveTrade {
-		for _, p := range game.Players {
-			if p.IsBot && p.UserID == game.ActiveTrade.TargetID {
-				targetBot = p
-				break
-			}
+	var tradeID string
+	for _, p := range game.Players {
+		if !p.IsBot || !p.IsActive {
+			continue
+		}
+		if incoming := incomingTrades(game, p.UserID); len(incoming) > 0 {
+			targetBot = p
+			tradeID = incoming[0].ID
+			break
 		}
 	}
 	s.mu.RUnlock()
@@ -1995,34 +1782,27 @@ func (s *GameService) checkBotTurn(game *domain.GameState) {
 
 			// Re-fetch game safely
 			g, ok := s.games[gameID]
-			if !ok || g.ActiveTrade == nil || g.ActiveTrade.TargetID != targetBot.UserID {
+			if !ok {
 				return
 			}
+			trade, open := g.Trades[tradeID]
+			if !open || !awaitingApproval(trade, targetBot.UserID) {
+				return
+			}
+			ref := json.RawMessage(fmt.Sprintf(`{"trade_id": "%s"}`, trade.ID))
 
-			trade := g.ActiveTrade
-
-			// Simple decision: Accept if they're offering more value than requesting
-			offerValue := trade.OfferCash
-			requestValue := trade.RequestCash
-
-			// Add property value estimates (rough: $200 per property)
-			offerValue += len(trade.OfferPropeties) * 200
-			requestValue += len(trade.RequestProperties) * 200
-
-			// Bot personality affects decision
-			profile := domain.GetBotProfile(targetBot.BotPersonalityID)
-			agreeable := profile.NegotiationSkill > 0.5 || profile.RiskTolerance > 0.6
-
-			// Accept if offer is better or bot is agreeable and it's close
-			threshold := int(float64(requestValue) * 0.8)
-			if offerValue > requestValue || (agreeable && offerValue >= threshold) {
-				log.Printf("Bot %s accepting trade from %s", targetBot.Name, trade.OffererName)
-				s.addBotThought(g, targetBot, fmt.Sprintf("✅ Acepto el trato de %s - me conviene", trade.OffererName))
-				s.handleAcceptTrade(g, targetBot.UserID, nil)
+			bot := s.getPlayer(g, targetBot.UserID)
+			if bot == nil {
+				return
+			}
+			if s.botService.acceptsTrade(g, bot, trade) {
+				log.Printf("Bot %s accepting trade from %s", bot.Name, trade.OffererName)
+				s.addBotThought(g, bot, fmt.Sprintf("✅ Acepto el trato de %s - me conviene", trade.OffererName))
+				s.runBotAction(g, bot, &domain.BotAction{Action: "ACCEPT_TRADE", Payload: ref})
 			} else {
-				log.Printf("Bot %s rejecting trade from %s", targetBot.Name, trade.OffererName)
-				s.addBotThought(g, targetBot, fmt.Sprintf("❌ Rechazo el trato de %s - no me conviene", trade.OffererName))
-				s.handleRejectTrade(g, targetBot.UserID, nil)
+				log.Printf("Bot %s rejecting trade from %s", bot.Name, trade.OffererName)
+				s.addBotThought(g, bot, fmt.Sprintf("❌ Rechazo el trato de %s - no me conviene", trade.OffererName))
+				s.runBotAction(g, bot, &domain.BotAction{Action: "REJECT_TRADE", Payload: ref})
 			}
 		}()
 		return
@@ -2051,100 +1831,122 @@ func (s *GameService) checkBotTurn(game *domain.GameState) {
 	s.executeBotTurn(game.GameID, currentPlayer)
 }
 
+// watchDutchAuction looks at the bots again after every price drop while a game has a Dutch
+// auction running. One goroutine per game does it however many broadcasts ask. Callers hold
+// s.mu.
+func (s *GameService) watchDutchAuction(gameID string) {
+	if s.dutchWatch[gameID] {
+		return
+	}
+	if s.dutchWatch == nil {
+		s.dutchWatch = make(map[string]bool)
+	}
+	s.dutchWatch[gameID] = true
+
+	go func() {
+		for {
+			time.Sleep(domain.DutchDropInterval)
+			s.mu.Lock()
+			g, ok := s.games[gameID]
+			if !ok || g.ActiveAuction == nil || !g.ActiveAuction.IsActive || auctionFormat(g.ActiveAuction) != domain.AuctionDutch {
+				delete(s.dutchWatch, gameID)
+				s.mu.Unlock()
+				return
+			}
+			s.mu.Unlock()
+			s.checkBotTurn(g)
+		}
+	}()
+}
+
+// Lobby and host actions a bot never takes; bots speak through addBotThought, not the chat
+var botDeniedActions = map[string]bool{
+	"JOIN_GAME":            true,
+	"START_GAME":           true,
+	"ADD_BOT":              true,
+	"UPDATE_PLAYER_CONFIG": true,
+	"SEND_CHAT":            true,
+}
+
 func (s *GameService) executeBotTurn(gameID string, bot *domain.PlayerState) {
-	s.mu.Lock()
+	s.mu.RLock()
 	game, ok := s.games[gameID]
-	s.mu.Unlock() // Unlock to allow bot service to think without blocking?
-	// actually bot service is just reading.
-	// We need to pass a SNAPSHOT or lock inside.
-	// BotService.GenerateDecision takes *GameState. Cleanest is to pass the pointer but be careful.
-	// Since GenerateDecision is read-only on GameState, it should be fine if we RLock inside or just copy.
-	// For MVP, we pass the game pointer.
-
-	if !ok {
+	var snapshot *domain.GameState
+	if ok {
+		snapshot = botView(game, bot.UserID)
+	}
+	s.mu.RUnlock()
+	if snapshot == nil {
+		return
+	}
+	me := s.getPlayer(snapshot, bot.UserID)
+	if me == nil {
 		return
 	}
 
-	action, err := s.botService.GenerateDecision(game, bot)
-	if err != nil {
-		log.Printf("Bot generation error: %v", err)
-		// Fallback: End Turn or Roll Dice if stuck
-		// To avoid infinite loops, just do nothing or force end?
+	// Strategies decide on a copy of the game, so a slow one (the LLM, a search) holds no lock
+	action := s.botService.GenerateDecision(snapshot, me)
+	if action == nil {
 		return
 	}
 
 	log.Printf("BOT ACTION [%s]: %s (%s)", bot.Name, action.Action, action.Reason)
 
-	// Publish bot's thought to chat
-	s.addBotThought(game, bot, fmt.Sprintf("🤖 %s: %s", action.Action, action.Reason))
-
-	// Execute Action
-	// We reuse existing handlers, but they expect JSON payloads.
-	// We can refactor handlers to accept structs or just marshal payload.
-
-	switch action.Action {
-	case "ROLL_DICE":
-		s.handleRollDice(game, bot.UserID)
-	case "END_TURN":
-		s.handleEndTurn(game, bot.UserID)
-	case "BUY_PROPERTY":
-		// Payload: { "property_id": "..." }
-		// But in our current HandleBuyProperty logic, we usually infer property from position?
-		// Let's check handleBuyProperty signature.
-		// It takes payload used to confirm ID?
-		// handleBuyProperty impl:
-		// var req struct { PropertyID string } ...
-		// if req.PropertyID != currentTile.PropertyID => error.
-		// So we construct payload.
-		currentTile := s.boardLayout[bot.Position] // ID
-		// Actually boardLayout value is PropertyID or Type.
-		// Better get tile object.
-		// Let's just build payload conformant to API.
-		// We found the property at bot position.
-		payload := fmt.Sprintf(`{"property_id": "%s"}`, currentTile)
-		s.handleBuyProperty(game, bot.UserID, json.RawMessage(payload))
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	case "START_AUCTION":
-		// payload: { "property_id": "..." }
-		currentTile := s.boardLayout[bot.Position]
-		payload := fmt.Sprintf(`{"property_id": "%s"}`, currentTile)
-		s.handleStartAuction(game, bot.UserID, json.RawMessage(payload))
+	player := s.getPlayer(game, bot.UserID)
+	if player == nil || s.games[gameID] != game {
+		return
+	}
 
-	case "DRAW_CARD":
-		s.handleDrawCard(game, bot.UserID)
+	// Publish bot's thought to chat
+	s.addBotThought(game, player, fmt.Sprintf("🤖 %s: %s", action.Action, action.Reason))
+	s.runBotAction(game, player, action)
+}
 
-	case "COLLECT_RENT":
-		s.handleCollectRent(game, bot.UserID)
+// runBotAction plays a bot's decision through the same dispatcher as human actions, so every
+// action a human can take is available to bots. Callers hold s.mu.
+func (s *GameService) runBotAction(game *domain.GameState, bot *domain.PlayerState, action *domain.BotAction) {
+	if botDeniedActions[action.Action] {
+		log.Printf("Bot %s can't take action '%s'", bot.Name, action.Action)
+		return
+	}
+	payload := botPayload(game, bot, action)
 
-	case "DECLARE_BANKRUPTCY":
-		s.handleDeclareBankruptcy(game, bot.UserID)
+	s.guardAction(game, action.Action, func() {
+		if s.dispatch(game, bot.UserID, action.Action, payload) {
+			return
+		}
+		// Unknown action: don't leave the table waiting on the bot's own turn
+		log.Printf("Bot %s tried unknown action '%s'", bot.Name, action.Action)
+		if game.CurrentTurnID == bot.UserID {
+			s.addLog(game, fmt.Sprintf("%s intentó una acción desconocida (%s) y termina su turno", bot.Name, action.Action), "ALERT")
+			s.handleEndTurn(game, bot.UserID)
+		}
+	})
+}
 
+// botPayload builds the handler payload of a bot action. Strategies may leave it implicit: the
+// property defaults to the one under the bot when buying or auctioning, and a bid takes its
+// Amount.
+func botPayload(game *domain.GameState, bot *domain.PlayerState, action *domain.BotAction) json.RawMessage {
+	if len(action.Payload) > 0 {
+		return action.Payload
+	}
+	propertyID := action.PropertyID
+	switch action.Action {
+	case "BUY_PROPERTY", "START_AUCTION":
+		if propertyID == "" {
+			propertyID = game.Board[bot.Position].PropertyID
+		}
 	case "BID":
-		// payload: { "amount": 123 }
-		payload := fmt.Sprintf(`{"amount": %d}`, action.Amount)
-		s.handleBid(game, bot.UserID, json.RawMessage(payload))
-
-	case "PASS_AUCTION":
-		s.handlePassAuction(game, bot.UserID)
-
-	case "BUY_BUILDING":
-		s.handleBuyBuilding(game, bot.UserID, action.Payload)
-
-	case "SELL_BUILDING":
-		// Payload should contain property_id
-		s.handleSellBuilding(game, bot.UserID, action.Payload)
-
-	case "MORTGAGE_PROPERTY":
-		s.handleMortgageProperty(game, bot.UserID, action.Payload)
-
-	case "INITIATE_TRADE":
-		s.handleInitiateTrade(game, bot.UserID, action.Payload)
-
-	default:
-		// Unknown action - fallback to END_TURN to prevent bot from getting stuck
-		log.Printf("Bot %s tried unknown action '%s' - falling back to END_TURN", bot.Name, action.Action)
-		s.handleEndTurn(game, bot.UserID)
+		return json.RawMessage(fmt.Sprintf(`{"amount": %d}`, action.Amount))
+	}
+	if propertyID == "" {
+		return nil
 	}
+	return json.RawMessage(fmt.Sprintf(`{"property_id": "%s"}`, propertyID))
 }
 
 func generateGameCode() string {
@@ -2156,74 +1958,21 @@ func generateGameCode() string {
 	return string(b)
 }
 
+// GetBoardConfig returns the tiles of the classic board
 func (s *GameService) GetBoardConfig() []domain.Tile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.initializeBoard()
 }
 
+// initializeBoard lays out the classic board, used by games until they start on another one.
+// Callers hold s.mu.
 func (s *GameService) initializeBoard() []domain.Tile {
-	tiles := make([]domain.Tile, 64)
-	for i := 0; i < 64; i++ {
-		// Look up layout
-		id, ok := s.boardLayout[i]
-
-		var tile domain.Tile
-		tile.ID = i
-		tile.PropertyID = id
-
-		if ok {
-			// Check if it's a property
-			if prop, exists := s.properties[id]; exists {
-				tile.Name = prop.Name
-				tile.Type = prop.Type
-				tile.Price = prop.Price
-				tile.Rent = prop.RentBase     // Base rent current
-				tile.RentRule = prop.RentRule // Pass to frontend
-
-				// Full info
-				tile.RentBase = prop.RentBase
-				tile.RentColorGroup = prop.RentColorGroup
-				tile.Rent1House = prop.Rent1House
-				tile.Rent2House = prop.Rent2House
-				tile.Rent3House = prop.Rent3House
-				tile.Rent4House = prop.Rent4House
-				tile.RentHotel = prop.RentHotel
-				tile.HouseCost = prop.HouseCost
-				tile.HotelCost = prop.HotelCost
-				tile.MortgageValue = prop.MortgageValue
-				tile.UnmortgageValue = prop.UnmortgageValue
-
-				tile.GroupIdentifier = prop.GroupID
-				tile.GroupName = prop.GroupName
-				tile.GroupColor = prop.GroupColor
-			} else {
-				// It's a special tile type string
-				// Override Corners based on Index for consistency
-				switch i {
-				case 0:
-					tile.Type = "GO"
-					tile.Name = "SALIDA"
-				case 16:
-					tile.Type = "JAIL"
-					tile.Name = "CÁRCEL"
-				case 32:
-					tile.Type = "FREE_PARKING"
-					tile.Name = "PARADA LIBRE" // Paso Libre
-				case 48:
-					tile.Type = "GO_TO_JAIL"
-					tile.Name = "VAYA A LA CÁRCEL"
-				default:
-					tile.Type = id
-					tile.Name = id
-				}
-			}
-		} else {
-			tile.Name = "Unknown"
-			tile.Type = "TILE"
-		}
-
-		tiles[i] = tile
+	classic, ok := s.boards[0]
+	if !ok {
+		return []domain.Tile{}
 	}
-	return tiles
+	return boards.Build(classic)
 }
 
 func (s *GameService) calculateRent(game *domain.GameState, tile *domain.Tile, diceRoll int) int {
@@ -2236,7 +1985,7 @@ func (s *GameService) calculateRent(game *domain.GameState, tile *domain.Tile, d
 	if tile.Type == "PARK" || tile.Type == "ATTRACTION" {
 		rent := tile.RentBase
 		if rent <= 0 {
-			rent = 25 // Minimum rent for parks/attractions
+			rent = inflate(game, 25) // Minimum rent for parks/attractions
 		}
 		return rent
 	}
@@ -2252,18 +2001,18 @@ func (s *GameService) calculateRent(game *domain.GameState, tile *domain.Tile, d
 		}
 		switch count {
 		case 1:
-			return diceRoll * 4
+			return inflate(game, diceRoll*4)
 		case 2:
-			return diceRoll * 10
+			return inflate(game, diceRoll*10)
 		case 3:
-			return diceRoll * 20
+			return inflate(game, diceRoll*20)
 		case 4:
-			return diceRoll * 40
+			return inflate(game, diceRoll*40)
 		default:
 			if count > 4 {
-				return diceRoll * 40
+				return inflate(game, diceRoll*40)
 			}
-			return diceRoll * 4 // Fallback
+			return inflate(game, diceRoll*4) // Fallback
 		}
 	}
 
@@ -2277,9 +2026,9 @@ func (s *GameService) calculateRent(game *domain.GameState, tile *domain.Tile, d
 			}
 		}
 		if count == 2 {
-			return diceRoll * 10
+			return inflate(game, diceRoll*10)
 		}
-		return diceRoll * 4
+		return inflate(game, diceRoll*4)
 	}
 
 	if tile.Type == "RAILROAD" {
@@ -2293,15 +2042,15 @@ func (s *GameService) calculateRent(game *domain.GameState, tile *domain.Tile, d
 		}
 		switch count {
 		case 1:
-			return 25
+			return inflate(game, 25)
 		case 2:
-			return 50
+			return inflate(game, 50)
 		case 3:
-			return 100
+			return inflate(game, 100)
 		case 4:
-			return 200
+			return inflate(game, 200)
 		default:
-			return 200
+			return inflate(game, 200)
 		}
 	}
 
@@ -2361,13 +2110,6 @@ func (s *GameService) trackTileVisit(game *domain.GameState, player *domain.Play
 	s.addLogWithMeta(game, "", "MOVEMENT", &pos, &player.UserID)
 }
 
-func (s *GameService) getLayoutID(index int) string {
-	if val, ok := s.boardLayout[index]; ok {
-		return val
-	}
-	return ""
-}
-
 func (s *GameService) handlePayRent(game *domain.GameState, userID string, payload json.RawMessage) {
 	var req struct {
 		PropertyID string `json:"property_id"`
@@ -2504,8 +2246,9 @@ func (s *GameService) handleBuyBuilding(game *domain.GameState, userID string, p
 	}
 
 	// 7. Execute Purchase
-	player.Balance -= cost
+	s.bankCollect(game, player, cost, domain.FlowBuilding, targetTile.Name)
 	targetTile.BuildingCount++
+	s.updateSharePrice(game, targetTile.GroupIdentifier)
 
 	// Log
 	levelName := "Casa"
@@ -2589,8 +2332,9 @@ func (s *GameService) handleSellBuilding(game *domain.GameState, userID string,
 
 	// 5. Execute Sale
 	player := s.getPlayer(game, userID)
-	player.Balance += refund
+	s.bankPay(game, player, refund, domain.FlowBuilding, targetTile.Name)
 	targetTile.BuildingCount--
+	s.updateSharePrice(game, targetTile.GroupIdentifier)
 
 	// Log
 	remaining := targetTile.BuildingCount
@@ -2617,6 +2361,16 @@ func (s *GameService) getPlayer(game *domain.GameState, userID string) *domain.P
 	return nil
 }
 
+// propertyTile returns the board tile of a property
+func (s *GameService) propertyTile(game *domain.GameState, propertyID string) *domain.Tile {
+	for i := range game.Board {
+		if game.Board[i].PropertyID == propertyID {
+			return &game.Board[i]
+		}
+	}
+	return nil
+}
+
 // handleMortgageProperty allows a player to mortgage a property they own
 // Rules: Cannot mortgage if property has buildings, cannot mortgage if any property in group has buildings
 func (s *GameService) handleMortgageProperty(game *domain.GameState, userID string, payload json.RawMessage) {
@@ -2680,7 +2434,7 @@ func (s *GameService) handleMortgageProperty(game *domain.GameState, userID stri
 	if mortgageValue == 0 {
 		mortgageValue = tile.Price / 2 // Default to 50% if not set
 	}
-	player.Balance += mortgageValue
+	s.bankPay(game, player, mortgageValue, domain.FlowMortgage, tile.Name)
 
 	s.addLog(game, player.Name+" hipotecó "+tile.Name+" por $"+strconv.Itoa(mortgageValue), "ACTION")
 	s.saveGame(game)
@@ -2744,7 +2498,7 @@ func (s *GameService) handleUnmortgageProperty(game *domain.GameState, userID st
 
 	// Execute unmortgage
 	tile.IsMortgaged = false
-	player.Balance -= unmortgageCost
+	s.bankCollect(game, player, unmortgageCost, domain.FlowMortgage, tile.Name)
 
 	s.addLog(game, player.Name+" deshipotecó "+tile.Name+" por $"+strconv.Itoa(unmortgageCost), "SUCCESS")
 	s.saveGame(game)
@@ -2824,7 +2578,18 @@ func (s *GameService) handleSellProperty(game *domain.GameState, userID string,
 	}
 
 	// Execute sale
-	player.Balance += salePrice
+	s.bankPay(game, player, salePrice, domain.FlowProperty, tile.Name)
+	proceeds := salePrice
+	if tile.IsMortgaged {
+		// The mortgage value was already received when mortgaging
+		if tile.MortgageValue > 0 {
+			proceeds += tile.MortgageValue
+		} else {
+			proceeds += tile.Price / 2
+		}
+	}
+	s.chargeCapitalGains(game, player, proceeds-costBasis(game, tile), "venta de "+tile.Name)
+	delete(game.CostBasis, req.PropertyID)
 	delete(game.PropertyOwnership, req.PropertyID)
 	tile.IsMortgaged = false // Clear mortgage status
 	tile.OwnerID = nil
@@ -2942,6 +2707,8 @@ func (s *GameService) handleSendChat(game *domain.GameState, userID string, payl
 						s.handleAcceptTrade(g, bot.UserID, action.Payload)
 					case "REJECT_TRADE":
 						s.handleRejectTrade(g, bot.UserID, action.Payload)
+					case "COUNTER_TRADE":
+						s.handleCounterTrade(g, bot.UserID, action.Payload)
 					case "OFFER_PURCHASE": // Hypothetical, treated as trade usually
 						// Map to appropriate handler if exists
 					}
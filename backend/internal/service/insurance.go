@@ -0,0 +1,207 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/cards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+	"github.com/gabriel3312cl/finances-game/backend/internal/valuation"
+)
+
+// QuoteInsurance prices a policy for a player without buying it
+func (s *GameService) QuoteInsurance(gameID string, userID string, req domain.InsuranceQuote) (*domain.InsuranceQuote, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	game, ok := s.games[gameID]
+	if !ok {
+		return nil, errors.New("game not found")
+	}
+	player := s.getPlayer(game, userID)
+	if player == nil {
+		return nil, errors.New("player not in game")
+	}
+	return s.quoteInsurance(game, player, req)
+}
+
+// quoteInsurance prices a policy from the expected covered loss over its term plus the bank margin
+func (s *GameService) quoteInsurance(game *domain.GameState, player *domain.PlayerState, req domain.InsuranceQuote) (*domain.InsuranceQuote, error) {
+	if req.Rounds <= 0 || req.Rounds > domain.InsuranceMaxRounds {
+		return nil, fmt.Errorf("rounds must be between 1 and %d", domain.InsuranceMaxRounds)
+	}
+	if req.CoveragePercent <= 0 || req.CoveragePercent > domain.InsuranceMaxCoverage {
+		return nil, fmt.Errorf("coverage must be between 1 and %d percent", domain.InsuranceMaxCoverage)
+	}
+	if req.Threshold < 0 {
+		return nil, errors.New("threshold cannot be negative")
+	}
+
+	var lossPerMove float64
+	switch req.Type {
+	case domain.InsuranceRent:
+		lossPerMove = s.expectedRentExcess(game, player, req.Threshold)
+	case domain.InsuranceRepair:
+		lossPerMove = s.expectedRepairCost(game, player)
+	default:
+		return nil, errors.New("unknown insurance type")
+	}
+
+	expected := int(lossPerMove * float64(req.Rounds) * float64(req.CoveragePercent) / 100)
+	premium := expected + expected*domain.InsuranceMarginPct/100
+	if premium < domain.InsuranceMinPremium {
+		premium = domain.InsuranceMinPremium
+	}
+
+	quote := req
+	quote.ExpectedLoss = expected
+	quote.Premium = premium
+	return &quote, nil
+}
+
+// expectedRentExcess is the expected rent above threshold paid by the player in one move,
+// based on the opponents' current developments and the landing probabilities
+func (s *GameService) expectedRentExcess(game *domain.GameState, player *domain.PlayerState, threshold int) float64 {
+	probs := valuation.LandingProbabilities(game)
+	expected := 0.0
+	for i := range game.Board {
+		tile := &game.Board[i]
+		ownerID, owned := game.PropertyOwnership[tile.PropertyID]
+		if !owned || ownerID == player.UserID || tile.IsMortgaged {
+			continue
+		}
+		rent := s.calculateRent(game, tile, 7) // Average roll
+		if rent > threshold {
+			expected += probs[i] * float64(rent-threshold)
+		}
+	}
+	return expected
+}
+
+// expectedRepairCost is the expected cost of "repair" cards for the player in one move
+func (s *GameService) expectedRepairCost(game *domain.GameState, player *domain.PlayerState) float64 {
+	probs := valuation.LandingProbabilities(game)
+	expected := 0.0
+	for i := range game.Board {
+		deck := s.cardDeck(game, game.Board[i].Type)
+		if len(deck) == 0 {
+			continue
+		}
+		for _, card := range deck {
+			effect, _ := cards.Parse(card.Effect)
+			for _, c := range effect {
+				if c.Action == cards.ActionRepair {
+					expected += probs[i] / float64(len(deck)) * float64(c.RepairCost(game, player.UserID))
+				}
+			}
+		}
+	}
+	return expected
+}
+
+func (s *GameService) handleBuyInsurance(game *domain.GameState, userID string, payload json.RawMessage) {
+	if game.Status != domain.GameStatusActive {
+		return
+	}
+	var req domain.InsuranceQuote
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return
+	}
+
+	player := s.getPlayer(game, userID)
+	if player == nil || !player.IsActive {
+		return
+	}
+
+	quote, err := s.quoteInsurance(game, player, req)
+	if err != nil {
+		s.addLog(game, "Seguro rechazado: "+err.Error(), "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+	if player.Balance < quote.Premium {
+		s.addLog(game, "Fondos insuficientes para la prima del seguro ($"+strconv.Itoa(quote.Premium)+")", "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+
+	s.initCreditProfile(player)
+	round := player.Credit.CurrentRound
+	s.bankCollect(game, player, quote.Premium, domain.FlowInsurance, quote.Type)
+	game.NextPolicyID++
+	player.Policies = append(player.Policies, &domain.InsurancePolicy{
+		ID:              fmt.Sprintf("POL-%d", game.NextPolicyID),
+		Type:            quote.Type,
+		CoveragePercent: quote.CoveragePercent,
+		Threshold:       quote.Threshold,
+		Premium:         quote.Premium,
+		StartRound:      round,
+		EndRound:        round + quote.Rounds,
+	})
+
+	s.addLog(game, player.Name+" contrató un seguro "+insuranceName(quote.Type)+" por "+strconv.Itoa(quote.Rounds)+" rondas (prima $"+strconv.Itoa(quote.Premium)+")", "ACTION")
+	s.broadcastGameState(game)
+}
+
+func insuranceName(policyType string) string {
+	if policyType == domain.InsuranceRepair {
+		return "de reparaciones"
+	}
+	return "de renta"
+}
+
+// settleInsuranceClaim pays the player's claim for a covered loss from their active policies
+// of the given type. The payout never exceeds the loss. Returns the amount paid by the bank.
+func (s *GameService) settleInsuranceClaim(game *domain.GameState, player *domain.PlayerState, policyType string, loss int) int {
+	if loss <= 0 || len(player.Policies) == 0 {
+		return 0
+	}
+	s.initCreditProfile(player)
+
+	payout := 0
+	for _, pol := range player.Policies {
+		if pol.Type != policyType || player.Credit.CurrentRound >= pol.EndRound {
+			continue
+		}
+		covered := loss
+		if policyType == domain.InsuranceRent {
+			covered = loss - pol.Threshold
+		}
+		if covered <= 0 {
+			continue
+		}
+		amount := covered * pol.CoveragePercent / 100
+		if payout+amount > loss {
+			amount = loss - payout
+		}
+		if amount <= 0 {
+			break
+		}
+		pol.ClaimsPaid += amount
+		payout += amount
+	}
+
+	if payout > 0 {
+		s.bankPay(game, player, payout, domain.FlowInsurance, "Reclamo "+policyType)
+		s.addLog(game, "🛡️ El seguro "+insuranceName(policyType)+" de "+player.Name+" cubrió $"+strconv.Itoa(payout), "SUCCESS")
+	}
+	return payout
+}
+
+// expirePolicies drops the policies whose term ended, called when the player's round advances
+func (s *GameService) expirePolicies(game *domain.GameState, player *domain.PlayerState) {
+	if len(player.Policies) == 0 {
+		return
+	}
+	active := player.Policies[:0]
+	for _, pol := range player.Policies {
+		if player.Credit.CurrentRound >= pol.EndRound {
+			s.addLog(game, "Venció el seguro "+insuranceName(pol.Type)+" de "+player.Name+" (reclamos pagados: $"+strconv.Itoa(pol.ClaimsPaid)+")", "INFO")
+			continue
+		}
+		active = append(active, pol)
+	}
+	player.Policies = active
+}
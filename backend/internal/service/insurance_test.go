@@ -0,0 +1,107 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/cards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func insuredGame() (*GameService, *domain.GameState) {
+	owner := "b"
+	game := &domain.GameState{
+		Status: domain.GameStatusActive,
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 1000, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 1000, IsActive: true},
+		},
+		Board: []domain.Tile{
+			{PropertyID: "GO", Type: domain.TileGo},
+			{PropertyID: "P1", Name: "Uno", Type: "PROPERTY", Price: 300, RentBase: 200, OwnerID: &owner},
+			{PropertyID: "P2", Name: "Dos", Type: "PROPERTY", Price: 100},
+			{PropertyID: "CH", Type: "CHANCE"},
+		},
+		PropertyOwnership: map[string]string{"P1": "b"},
+	}
+	s := &GameService{}
+	s.initTreasury(game)
+	s.initLedger(game)
+	return s, game
+}
+
+func TestInsurance_RentPolicyPricesPaysAndExpires(t *testing.T) {
+	s, game := insuredGame()
+	a := game.Players[0]
+
+	// The premium is the expected covered loss over the term plus the bank margin
+	req := domain.InsuranceQuote{Type: domain.InsuranceRent, Rounds: 2, CoveragePercent: 50, Threshold: 50}
+	quote, err := s.quoteInsurance(game, a, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rent := s.calculateRent(game, &game.Board[1], 7)
+	expected := int(float64(rent-50) / 4 * 2 * 50 / 100) // Uniform landing odds on four tiles
+	if quote.ExpectedLoss != expected || quote.Premium != expected+expected*domain.InsuranceMarginPct/100 {
+		t.Errorf("quote %+v, want expected loss %d", quote, expected)
+	}
+	if _, err := s.quoteInsurance(game, a, domain.InsuranceQuote{Type: domain.InsuranceRent, Rounds: 2, CoveragePercent: 95}); err == nil {
+		t.Error("coverage above the maximum was quoted")
+	}
+
+	s.handleBuyInsurance(game, "a", []byte(`{"type": "RENT", "rounds": 2, "coverage_percent": 50, "threshold": 50}`))
+	if len(a.Policies) != 1 || a.Policies[0].ID != "POL-1" || a.Balance != 1000-quote.Premium {
+		t.Fatalf("policies %+v, balance %d", a.Policies, a.Balance)
+	}
+
+	// Half of the rent above the threshold comes back
+	before := a.Balance
+	s.payRent(game, a, game.Players[1], &game.Board[1], 250)
+	if paid := before - a.Balance; paid != 250-100 {
+		t.Errorf("A paid %d net of the claim, want 150", paid)
+	}
+	if a.Policies[0].ClaimsPaid != 100 {
+		t.Errorf("claims paid %d", a.Policies[0].ClaimsPaid)
+	}
+
+	// Two laps later the policy is gone
+	s.passGo(game, a, 0)
+	if len(a.Policies) != 1 {
+		t.Fatal("the policy expired a round early")
+	}
+	s.passGo(game, a, 0)
+	if len(a.Policies) != 0 {
+		t.Error("the policy outlived its term")
+	}
+	if err := s.checkInvariants(game); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInsurance_RepairClaimNeverExceedsTheLoss(t *testing.T) {
+	s, game := insuredGame()
+	a := game.Players[0]
+	owner := "a"
+	game.Board[2].OwnerID, game.Board[2].BuildingCount = &owner, 2
+	game.PropertyOwnership["P2"] = "a"
+	// Two overlapping policies could pay 180% of a loss
+	a.Policies = []*domain.InsurancePolicy{
+		{ID: "POL-1", Type: domain.InsuranceRepair, CoveragePercent: 90, EndRound: 3},
+		{ID: "POL-2", Type: domain.InsuranceRepair, CoveragePercent: 90, EndRound: 3},
+	}
+
+	effect, err := cards.Parse("repair:25:100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	card := &domain.Card{Title: "Reparaciones", Effect: "repair:25:100"}
+	s.applyCardClause(game, a, card, &effect[0])
+	if a.Balance != 1000 {
+		t.Errorf("a $50 repair fully covered left A with %d", a.Balance)
+	}
+	if paid := a.Policies[0].ClaimsPaid + a.Policies[1].ClaimsPaid; paid != 50 {
+		t.Errorf("policies paid %d for a $50 loss", paid)
+	}
+	if err := s.checkInvariants(game); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// giveItem adds items to a player's inventory
+func giveItem(player *domain.PlayerState, item string, n int) {
+	if n <= 0 {
+		return
+	}
+	if player.Inventory == nil {
+		player.Inventory = make(domain.Inventory)
+	}
+	player.Inventory[item] += n
+}
+
+// takeItem removes items from a player's inventory. Returns false, taking nothing, if they
+// don't hold enough.
+func takeItem(player *domain.PlayerState, item string, n int) bool {
+	if n <= 0 {
+		return true
+	}
+	if player.Inventory[item] < n {
+		return false
+	}
+	player.Inventory[item] -= n
+	if player.Inventory[item] == 0 {
+		delete(player.Inventory, item)
+	}
+	return true
+}
+
+func (s *GameService) handleUseItem(game *domain.GameState, userID string, payload json.RawMessage) {
+	var req struct {
+		Item string `json:"item"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return
+	}
+	s.useItem(game, userID, req.Item)
+}
+
+// useItem plays a usable item from the player's inventory. Shields and rent doublers can't be
+// played: they are spent when the next rent is owed or collected.
+func (s *GameService) useItem(game *domain.GameState, userID string, item string) {
+	player := s.getPlayer(game, userID)
+	if player == nil || !player.IsActive {
+		return
+	}
+	info, known := domain.Items[item]
+	if !known || !info.Usable {
+		s.addLog(game, "Ese objeto no se puede usar.", "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+	if player.Inventory[item] <= 0 {
+		s.addLog(game, player.Name+" no tiene '"+info.Name+"'.", "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+
+	switch item {
+	case domain.ItemJailFree:
+		if !player.InJail {
+			return
+		}
+		takeItem(player, item, 1)
+		player.InJail = false
+		player.JailTurns = 0
+		s.addLog(game, player.Name+" usó una tarjeta 'Sal de la Cárcel'!", "SUCCESS")
+
+	case domain.ItemMoveAgain:
+		// After a normal roll on their own turn, with nothing left to settle
+		if game.Status != domain.GameStatusActive || game.CurrentTurnID != userID || player.InJail ||
+			game.Dice[0] == 0 || game.Dice[0] == game.Dice[1] || game.ActiveAuction != nil ||
+			(game.PendingTax != nil && game.PendingTax.PlayerID == userID) {
+			s.addLog(game, "Solo puedes volver a tirar después de tu tirada.", "ALERT")
+			s.broadcastGameState(game)
+			return
+		}
+		takeItem(player, item, 1)
+		game.Dice = [2]int{0, 0}
+		game.DrawnCard = nil
+		s.addLog(game, player.Name+" usó '"+info.Name+"' y vuelve a tirar los dados", "SUCCESS")
+	}
+	s.broadcastGameState(game)
+}
+
+// rentItems applies the shield of the tenant and the rent doubler of the owner to a rent about
+// to be paid. Returns the rent due and whether a shield cancelled it.
+func (s *GameService) rentItems(game *domain.GameState, tenant, owner *domain.PlayerState, rent int) (int, bool) {
+	if rent <= 0 {
+		return rent, false
+	}
+	if takeItem(tenant, domain.ItemShield, 1) {
+		s.addLog(game, "🛡️ "+tenant.Name+" usó un Escudo y no paga los $"+strconv.Itoa(rent)+" de renta a "+owner.Name, "INFO")
+		return 0, true
+	}
+	if takeItem(owner, domain.ItemRentDoubler, 1) {
+		s.addLog(game, "💰 "+owner.Name+" usó 'Renta doble': cobra $"+strconv.Itoa(rent*2), "INFO")
+		return rent * 2, false
+	}
+	return rent, false
+}
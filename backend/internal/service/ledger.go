@@ -0,0 +1,308 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// savingsAccount names a player's deposit account in the ledger
+func savingsAccount(userID string) string {
+	return domain.SavingsAccountPrefix + userID
+}
+
+// initLedger opens the book with the cash the bank issued to each player at game start
+func (s *GameService) initLedger(game *domain.GameState) {
+	ledger := &domain.Ledger{
+		Accounts:     make(map[string]int),
+		Transactions: []domain.LedgerTransaction{},
+	}
+	game.Ledger = ledger
+	for _, p := range game.Players {
+		s.postTransaction(game, domain.AccountBank, p.UserID, p.Balance, domain.ReasonIssue, "")
+		if p.Savings > 0 {
+			s.postTransaction(game, domain.AccountBank, savingsAccount(p.UserID), p.Savings, domain.ReasonIssue, "")
+		}
+	}
+}
+
+// accountField returns the game field holding an account's money; the bank has none
+func (s *GameService) accountField(game *domain.GameState, account string) *int {
+	switch {
+	case account == domain.AccountBank:
+		return nil
+	case account == domain.AccountFreeParking:
+		return &game.FreeParkingPot
+	case strings.HasPrefix(account, domain.SavingsAccountPrefix):
+		if p := s.getPlayer(game, strings.TrimPrefix(account, domain.SavingsAccountPrefix)); p != nil {
+			return &p.Savings
+		}
+	default:
+		if p := s.getPlayer(game, account); p != nil {
+			return &p.Balance
+		}
+	}
+	return nil
+}
+
+// accountOwner returns the player behind an account, or the account name itself
+func accountOwner(account string) string {
+	return strings.TrimPrefix(account, domain.SavingsAccountPrefix)
+}
+
+// transfer is the only way money moves: it updates both accounts, books the transaction in
+// the ledger and, when the bank or its Free Parking pot is involved, in the treasury
+func (s *GameService) transfer(game *domain.GameState, from, to string, amount int, reason string, detail string) {
+	if amount <= 0 || from == to {
+		return
+	}
+	if field := s.accountField(game, from); field != nil {
+		*field -= amount
+	}
+	if field := s.accountField(game, to); field != nil {
+		*field += amount
+	}
+	s.postTransaction(game, from, to, amount, reason, detail)
+
+	if bankSide(to) && !bankSide(from) {
+		s.recordFlow(game, domain.FlowIn, accountOwner(from), amount, reason, detail)
+	} else if bankSide(from) && !bankSide(to) {
+		s.recordFlow(game, domain.FlowOut, accountOwner(to), amount, reason, detail)
+	}
+}
+
+// postTransaction books a transaction in the ledger without touching the game fields
+// (no-op for games started before the ledger existed)
+func (s *GameService) postTransaction(game *domain.GameState, from, to string, amount int, reason string, detail string) {
+	ledger := game.Ledger
+	if ledger == nil {
+		return
+	}
+	ledger.Accounts[from] -= amount
+	ledger.Accounts[to] += amount
+	ledger.NextID++
+	ledger.Transactions = append(ledger.Transactions, domain.LedgerTransaction{
+		ID:        ledger.NextID,
+		Timestamp: time.Now().Unix(),
+		From:      from,
+		To:        to,
+		Amount:    amount,
+		Reason:    reason,
+		Detail:    detail,
+	})
+	if len(ledger.Transactions) > domain.MaxLedgerEntries {
+		ledger.Transactions = ledger.Transactions[len(ledger.Transactions)-domain.MaxLedgerEntries:]
+	}
+}
+
+// checkInvariants verifies that no money was created or destroyed: the ledger balances, every
+// account matches the game state and the treasury reconciles with the money in play
+func (s *GameService) checkInvariants(game *domain.GameState) error {
+	ledger := game.Ledger
+	if ledger == nil {
+		return nil
+	}
+
+	sum := 0
+	for _, v := range ledger.Accounts {
+		sum += v
+	}
+	if sum != 0 {
+		return fmt.Errorf("ledger accounts add up to %d", sum)
+	}
+
+	for _, p := range game.Players {
+		if ledger.Accounts[p.UserID] != p.Balance {
+			return fmt.Errorf("balance of %s is %d, ledger says %d", p.Name, p.Balance, ledger.Accounts[p.UserID])
+		}
+		if ledger.Accounts[savingsAccount(p.UserID)] != p.Savings {
+			return fmt.Errorf("savings of %s are %d, ledger says %d", p.Name, p.Savings, ledger.Accounts[savingsAccount(p.UserID)])
+		}
+		if p.Savings < 0 {
+			return fmt.Errorf("savings of %s are negative", p.Name)
+		}
+		if p.Loan < 0 {
+			return fmt.Errorf("loan of %s is negative", p.Name)
+		}
+	}
+
+	if ledger.Accounts[domain.AccountFreeParking] != game.FreeParkingPot {
+		return fmt.Errorf("free parking pot is %d, ledger says %d", game.FreeParkingPot, ledger.Accounts[domain.AccountFreeParking])
+	}
+	if game.FreeParkingPot < 0 {
+		return errors.New("free parking pot is negative")
+	}
+	if issued := -ledger.Accounts[domain.AccountBank] - game.FreeParkingPot; issued != moneyInPlay(game) {
+		return fmt.Errorf("bank issued %d but %d is in play", issued, moneyInPlay(game))
+	}
+	if game.Treasury != nil {
+		if d := treasuryReport(game).Discrepancy; d != 0 {
+			return fmt.Errorf("treasury is off by %d", d)
+		}
+	}
+	return nil
+}
+
+// guardAction runs an action and rolls the game back if it breaks the money invariants.
+// A rollback restores the players and the active auction in place, so pointers to them stay
+// valid; pointers into anything else the action touched (trades, tiles, the market) taken
+// before the call point to the discarded state afterwards.
+func (s *GameService) guardAction(game *domain.GameState, action string, apply func()) {
+	if game.Ledger == nil {
+		apply()
+		return
+	}
+	// The history is append-only: it stays out of the snapshot, and rolling it back is keeping
+	// the slices it had
+	history := detachHistory(game)
+	before, err := json.Marshal(game)
+	history.attach(game)
+	if err != nil {
+		apply()
+		return
+	}
+	auction := game.ActiveAuction
+
+	apply()
+
+	violation := s.checkInvariants(game)
+	if violation == nil {
+		return
+	}
+	log.Printf("Money invariant violated in game %s by %s: %v", game.GameID, action, violation)
+
+	var restored domain.GameState
+	if err := json.Unmarshal(before, &restored); err != nil {
+		log.Printf("Error restoring game %s: %v", game.GameID, err)
+		return
+	}
+	restoreMaps(&restored)
+	history.attach(&restored)
+	for i, p := range restored.Players {
+		if current := s.getPlayer(game, p.UserID); current != nil {
+			// Keep the fields that are not serialized, and the pointer
+			p.LastBotChatTime = current.LastBotChatTime
+			p.LastBotTradeTime = current.LastBotTradeTime
+			*current = *p
+			restored.Players[i] = current
+		}
+	}
+	if auction != nil && restored.ActiveAuction != nil {
+		*auction = *restored.ActiveAuction
+		restored.ActiveAuction = auction
+	}
+	*game = restored
+
+	s.addLog(game, "Acción "+action+" rechazada: descuadra las cuentas del banco ("+violation.Error()+")", "ALERT")
+	s.broadcastGameState(game)
+}
+
+// gameHistory holds the append-only records of a game: logs, chat, ledger transactions and
+// treasury entries
+type gameHistory struct {
+	logs         []domain.EventLog
+	chat         []domain.ChatMessage
+	transactions []domain.LedgerTransaction
+	entries      []domain.TreasuryEntry
+}
+
+// detachHistory takes the history out of a game, to be put back with attach
+func detachHistory(game *domain.GameState) gameHistory {
+	h := gameHistory{logs: game.Logs, chat: game.ChatMessages}
+	game.Logs, game.ChatMessages = nil, nil
+	if game.Ledger != nil {
+		h.transactions, game.Ledger.Transactions = game.Ledger.Transactions, nil
+	}
+	if game.Treasury != nil {
+		h.entries, game.Treasury.Entries = game.Treasury.Entries, nil
+	}
+	return h
+}
+
+// attach puts a detached history into a game
+func (h gameHistory) attach(game *domain.GameState) {
+	game.Logs, game.ChatMessages = h.logs, h.chat
+	if game.Ledger != nil {
+		game.Ledger.Transactions = h.transactions
+	}
+	if game.Treasury != nil {
+		game.Treasury.Entries = h.entries
+	}
+}
+
+// restoreMaps recreates the maps a JSON round trip leaves nil because they were empty
+// (omitempty), so the handlers can keep writing to them without checks
+func restoreMaps(game *domain.GameState) {
+	if game.PropertyOwnership == nil {
+		game.PropertyOwnership = make(map[string]string)
+	}
+	if game.TileVisits == nil {
+		game.TileVisits = make(map[int]int)
+	}
+	if game.OrderRolls == nil && game.Status == domain.GameStatusRollingOrder {
+		game.OrderRolls = make(map[string]int)
+	}
+	for _, t := range game.Trades {
+		if t.Approvals == nil {
+			t.Approvals = make(map[string]bool)
+		}
+	}
+	if a := game.ActiveAuction; a != nil {
+		if a.PassedPlayers == nil {
+			a.PassedPlayers = make(map[string]bool)
+		}
+		if a.SealedBids == nil && isSealedAuction(a) {
+			a.SealedBids = make(map[string]int)
+		}
+	}
+	if m := game.StockMarket; m != nil {
+		for _, stock := range m.Stocks {
+			if stock.Holdings == nil {
+				stock.Holdings = make(map[string]int)
+			}
+		}
+	}
+	if l := game.Ledger; l != nil && l.Accounts == nil {
+		l.Accounts = make(map[string]int)
+	}
+	if t := game.Treasury; t != nil {
+		if t.Inflows == nil {
+			t.Inflows = make(map[string]int)
+		}
+		if t.Outflows == nil {
+			t.Outflows = make(map[string]int)
+		}
+	}
+}
+
+// GetLedger returns the game's double-entry book, to its players
+func (s *GameService) GetLedger(gameID string, userID string) (*domain.Ledger, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	game, ok := s.games[gameID]
+	if !ok {
+		return nil, errors.New("game not found")
+	}
+	if s.getPlayer(game, userID) == nil {
+		return nil, errors.New("player not in game")
+	}
+	if game.Ledger == nil {
+		return nil, errors.New("game has no ledger (started before it was introduced)")
+	}
+	// Copy so the caller can encode it after the lock is released
+	ledger := &domain.Ledger{
+		Accounts:     make(map[string]int, len(game.Ledger.Accounts)),
+		Transactions: append([]domain.LedgerTransaction(nil), game.Ledger.Transactions...),
+		NextID:       game.Ledger.NextID,
+	}
+	for k, v := range game.Ledger.Accounts {
+		ledger.Accounts[k] = v
+	}
+	return ledger, nil
+}
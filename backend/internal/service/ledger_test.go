@@ -0,0 +1,85 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func TestLedger_TransfersKeepInvariants(t *testing.T) {
+	game := &domain.GameState{
+		Players: []*domain.PlayerState{
+			{UserID: "p1", Name: "Ana", Balance: 1500, IsActive: true},
+			{UserID: "p2", Name: "Beto", Balance: 1500, IsActive: true},
+		},
+	}
+	s := &GameService{}
+	s.initTreasury(game)
+	s.initLedger(game)
+
+	if err := s.checkInvariants(game); err != nil {
+		t.Fatalf("fresh game violates invariants: %v", err)
+	}
+
+	s.transfer(game, "p1", "p2", 200, domain.ReasonRent, "")
+	s.bankPay(game, game.Players[0], 200, domain.FlowSalary, "")
+	s.bankCollect(game, game.Players[1], 50, domain.FlowTax, "")
+	s.transfer(game, "p2", savingsAccount("p2"), 300, domain.ReasonSavingsDeposit, "")
+	s.transfer(game, "p1", domain.AccountFreeParking, 100, domain.FlowFine, "")
+
+	if err := s.checkInvariants(game); err != nil {
+		t.Fatalf("transfers violate invariants: %v", err)
+	}
+	if game.Players[0].Balance != 1400 || game.Players[1].Balance != 1350 || game.Players[1].Savings != 300 || game.FreeParkingPot != 100 {
+		t.Fatalf("unexpected balances: %d %d %d %d", game.Players[0].Balance, game.Players[1].Balance, game.Players[1].Savings, game.FreeParkingPot)
+	}
+
+	// The pot is the bank's: fines into it and the jackpot out of it are treasury flows
+	s.collectFreeParking(game, game.Players[1])
+	report := treasuryReport(game)
+	if report.Treasury.Inflows[domain.FlowFine] != 100 || report.Treasury.Outflows[domain.FlowJackpot] != 100 || report.Discrepancy != 0 || report.FreeParkingPot != 0 {
+		t.Fatalf("pot missing from the treasury: %+v", report)
+	}
+	if err := s.checkInvariants(game); err != nil {
+		t.Fatalf("jackpot violates invariants: %v", err)
+	}
+
+	// Money created outside the ledger must be detected
+	game.Players[0].Balance += 10
+	if err := s.checkInvariants(game); err == nil {
+		t.Fatal("expected a violation for an unbooked balance change")
+	}
+}
+
+func TestGuardAction_RollbackKeepsGamePlayable(t *testing.T) {
+	game := &domain.GameState{
+		Status:     domain.GameStatusRollingOrder,
+		OrderRolls: map[string]int{},
+		Players: []*domain.PlayerState{
+			{UserID: "p1", Name: "Ana", Balance: 1500, IsActive: true},
+			{UserID: "p2", Name: "Beto", Balance: 1500, IsActive: true},
+		},
+	}
+	s := &GameService{}
+	s.initTreasury(game)
+	s.initLedger(game)
+	ana := game.Players[0]
+	transactions := len(game.Ledger.Transactions)
+
+	s.guardAction(game, "CHEAT", func() {
+		s.bankPay(game, ana, 100, domain.FlowSalary, "")
+		ana.Balance += 10 // Unbooked
+	})
+
+	if game.Players[0] != ana || ana.Balance != 1500 || len(game.Ledger.Transactions) != transactions {
+		t.Fatalf("rollback left balance %d and %d transactions", ana.Balance, len(game.Ledger.Transactions))
+	}
+	if err := s.checkInvariants(game); err != nil {
+		t.Fatalf("rolled back game violates invariants: %v", err)
+	}
+	// Empty maps come back usable
+	s.handleRollOrder(game, "p1")
+	if _, rolled := game.OrderRolls["p1"]; !rolled {
+		t.Error("the roll after a rollback was lost")
+	}
+}
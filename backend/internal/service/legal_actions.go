@@ -0,0 +1,247 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// GetLegalActions returns what a player can do right now in a game
+func (s *GameService) GetLegalActions(gameID string, userID string) ([]domain.LegalAction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	game, ok := s.games[gameID]
+	if !ok {
+		return nil, errors.New("game not found")
+	}
+	if s.getPlayer(game, userID) == nil {
+		return nil, errors.New("player not in game")
+	}
+	return s.LegalActions(game, userID), nil
+}
+
+// sendLegalActions answers a GET_LEGAL_ACTIONS query with a LEGAL_ACTIONS message to the
+// player who asked. Callers hold s.mu.
+func (s *GameService) sendLegalActions(game *domain.GameState, userID string) {
+	s.sendToUser(game.GameID, userID, "LEGAL_ACTIONS", s.LegalActions(game, userID))
+}
+
+// LegalActions lists the actions a player can take in the current state of a game, with the
+// values their payloads accept. It follows the checks of the action handlers, so whatever it
+// lists goes through unless the game changes first. Callers hold s.mu.
+func (s *GameService) LegalActions(game *domain.GameState, playerID string) []domain.LegalAction {
+	player := s.getPlayer(game, playerID)
+	if player == nil || !player.IsActive {
+		return nil
+	}
+	var actions []domain.LegalAction
+	add := func(a domain.LegalAction) {
+		actions = append(actions, a)
+	}
+
+	switch game.Status {
+	case domain.GameStatusWaiting:
+		if len(game.Players) > 0 && game.Players[0].UserID == playerID {
+			add(domain.LegalAction{Action: "ADD_BOT", Description: "Añadir un bot a la partida"})
+			if len(game.Players) >= 2 {
+				add(domain.LegalAction{Action: "START_GAME", Description: "Iniciar la partida"})
+			}
+		}
+		return actions
+	case domain.GameStatusRollingOrder:
+		if _, rolled := game.OrderRolls[playerID]; !rolled {
+			add(domain.LegalAction{Action: "ROLL_ORDER", Description: "Tirar los dados para el orden de juego"})
+		}
+		return actions
+	case domain.GameStatusActive:
+	default:
+		return nil
+	}
+	myTurn := game.CurrentTurnID == playerID
+
+	switch {
+	case player.Balance < 0:
+		// In debt: raise cash or give up
+		if ids := sellableBuildings(game, playerID); myTurn && len(ids) > 0 {
+			add(domain.LegalAction{Action: "SELL_BUILDING", Description: "Vender una casa u hotel a mitad de precio", PropertyIDs: ids})
+		}
+		add(domain.LegalAction{Action: "DECLARE_BANKRUPTCY", Description: "Declararse en bancarrota y abandonar la partida"})
+
+	case game.PendingTax != nil && game.PendingTax.PlayerID == playerID:
+		add(domain.LegalAction{
+			Action:      "PAY_INCOME_TAX",
+			Description: fmt.Sprintf("Pagar el impuesto: FLAT $%d o PERCENT $%d", game.PendingTax.FlatAmount, game.PendingTax.PercentAmount),
+			Options:     []string{domain.IncomeTaxFlat, domain.IncomeTaxPercent},
+		})
+
+	case game.ActiveAuction != nil && game.ActiveAuction.IsActive:
+		auction := game.ActiveAuction
+		_, sealed := auction.SealedBids[playerID]
+		if auction.BidderID != playerID && !auction.PassedPlayers[playerID] && !sealed {
+			minBid, maxBid := minNextBid(auction), player.Balance
+			description := fmt.Sprintf("Ofertar en la subasta (%s)", auctionFormat(auction))
+			switch {
+			case isSealedAuction(auction):
+				description += "; la oferta va en sobre cerrado y solo se hace una vez"
+			case auctionFormat(auction) == domain.AuctionDutch:
+				// The clock's price is the only bid
+				minBid = dutchPrice(auction, time.Now())
+				maxBid = minBid
+				description = fmt.Sprintf("Aceptar el precio actual de la subasta holandesa, $%d", minBid)
+			}
+			if player.Balance >= minBid {
+				add(domain.LegalAction{Action: "BID", Description: description, MinAmount: minBid, MaxAmount: maxBid})
+			}
+			add(domain.LegalAction{Action: "PASS_AUCTION", Description: "Retirarse de la subasta"})
+		}
+
+	case myTurn && game.Dice[0] == 0:
+		add(domain.LegalAction{Action: "ROLL_DICE", Description: "Tirar los dados"})
+		if player.InJail && player.Balance >= 50 { // The bail handlePayBail charges
+			add(domain.LegalAction{Action: "PAY_BAIL", Description: "Pagar $50 de fianza para salir de la cárcel"})
+		}
+		if player.InJail && player.Inventory[domain.ItemJailFree] > 0 {
+			add(domain.LegalAction{Action: "USE_ITEM", Description: "Usar la tarjeta para salir de la cárcel", Options: []string{domain.ItemJailFree}})
+		}
+
+	case myTurn:
+		// Landed: cards and unowned properties are resolved before anything else
+		tile := game.Board[player.Position]
+		if _, owned := game.PropertyOwnership[tile.PropertyID]; (tile.Type == "CHANCE" || tile.Type == "COMMUNITY") && game.DrawnCard == nil {
+			add(domain.LegalAction{Action: "DRAW_CARD", Description: "Sacar la carta de la casilla"})
+		} else if tile.Price > 0 && !owned {
+			if player.Balance >= tile.Price {
+				add(domain.LegalAction{Action: "BUY_PROPERTY", Description: fmt.Sprintf("Comprar %s por $%d", tile.Name, tile.Price), PropertyIDs: []string{tile.PropertyID}})
+			}
+			add(domain.LegalAction{Action: "START_AUCTION", Description: fmt.Sprintf("Subastar %s entre todos los jugadores", tile.Name), PropertyIDs: []string{tile.PropertyID}})
+		} else {
+			add(domain.LegalAction{Action: "END_TURN", Description: "Terminar el turno"})
+			if ids := buildable(game, player); len(ids) > 0 {
+				add(domain.LegalAction{Action: "BUY_BUILDING", Description: "Construir una casa, u hotel sobre 4 casas, parejo en el grupo", PropertyIDs: ids})
+			}
+			if ids := sellableBuildings(game, playerID); len(ids) > 0 {
+				add(domain.LegalAction{Action: "SELL_BUILDING", Description: "Vender una casa u hotel a mitad de precio", PropertyIDs: ids})
+			}
+			if player.Inventory[domain.ItemMoveAgain] > 0 && !player.InJail && game.Dice[0] != game.Dice[1] {
+				add(domain.LegalAction{Action: "USE_ITEM", Description: "Usar el objeto para tirar de nuevo", Options: []string{domain.ItemMoveAgain}})
+			}
+		}
+	}
+
+	// Whenever the game is on: money management, mortgages and trades
+	if ids := mortgageable(game, playerID); len(ids) > 0 {
+		add(domain.LegalAction{Action: "MORTGAGE_PROPERTY", Description: "Hipotecar una propiedad para obtener efectivo", PropertyIDs: ids})
+	}
+	if ids := ownedProperties(game, playerID, func(t domain.Tile) bool { return t.IsMortgaged && t.UnmortgageValue <= player.Balance }); len(ids) > 0 {
+		add(domain.LegalAction{Action: "UNMORTGAGE_PROPERTY", Description: "Levantar la hipoteca de una propiedad", PropertyIDs: ids})
+	}
+	if player.Savings > 0 {
+		add(domain.LegalAction{Action: "WITHDRAW_SAVINGS", Description: "Retirar de la cuenta de ahorro", MinAmount: 1, MaxAmount: player.Savings})
+	}
+	if player.Balance > 0 {
+		add(domain.LegalAction{Action: "DEPOSIT_SAVINGS", Description: fmt.Sprintf("Depositar en la cuenta de ahorro (%d%% por ronda)", savingsRate(game)), MinAmount: 1, MaxAmount: player.Balance})
+	}
+	if available := s.getCreditLimit(creditScore(player)) - player.Loan; available > 0 {
+		add(domain.LegalAction{Action: "TAKE_LOAN", Description: "Pedir un préstamo al banco", MinAmount: 1, MaxAmount: available})
+	}
+	if payable := min(player.Loan, player.Balance); payable > 0 {
+		add(domain.LegalAction{Action: "PAY_LOAN", Description: fmt.Sprintf("Pagar el préstamo (debes $%d)", player.Loan), MinAmount: 1, MaxAmount: payable})
+	}
+	if waiting := sortedTrades(game, func(t *domain.TradeOffer) bool { return awaitingApproval(t, playerID) }); len(waiting) > 0 {
+		ids := make([]string, len(waiting))
+		for i, t := range waiting {
+			ids[i] = t.ID
+		}
+		add(domain.LegalAction{Action: "ACCEPT_TRADE", Description: "Aceptar una oferta de intercambio", TradeIDs: ids})
+		add(domain.LegalAction{Action: "REJECT_TRADE", Description: "Rechazar una oferta de intercambio", TradeIDs: ids})
+		add(domain.LegalAction{Action: "COUNTER_TRADE", Description: "Responder una oferta con una contraoferta", TradeIDs: ids})
+	}
+	if sent := sortedTrades(game, func(t *domain.TradeOffer) bool { return t.OffererID == playerID }); len(sent) < domain.MaxOpenTradesPerPlayer {
+		var targets []string
+		for _, p := range game.Players {
+			if p.IsActive && p.UserID != playerID {
+				targets = append(targets, p.UserID)
+			}
+		}
+		if len(targets) > 0 {
+			add(domain.LegalAction{Action: "INITIATE_TRADE", Description: "Proponer un intercambio a otro jugador", TargetIDs: targets})
+		}
+	}
+	return actions
+}
+
+// creditScore is a player's current credit score, the starting one before any credit activity
+func creditScore(player *domain.PlayerState) int {
+	if player.Credit == nil {
+		return 700
+	}
+	return player.Credit.Score
+}
+
+// ownedProperties lists the IDs of a player's properties that pass a filter
+func ownedProperties(game *domain.GameState, userID string, keep func(domain.Tile) bool) []string {
+	var ids []string
+	for _, t := range game.Board {
+		if t.OwnerID != nil && *t.OwnerID == userID && keep(t) {
+			ids = append(ids, t.PropertyID)
+		}
+	}
+	return ids
+}
+
+// mortgageable lists a player's properties that can be mortgaged: not yet, and with no
+// buildings in their group
+func mortgageable(game *domain.GameState, userID string) []string {
+	built := make(map[string]bool)
+	for _, t := range game.Board {
+		if t.GroupIdentifier != "" && t.BuildingCount > 0 {
+			built[t.GroupIdentifier] = true
+		}
+	}
+	return ownedProperties(game, userID, func(t domain.Tile) bool {
+		return !t.IsMortgaged && (t.GroupIdentifier == "" || !built[t.GroupIdentifier])
+	})
+}
+
+// buildable lists where a player can afford a building: color groups owned whole, without
+// mortgages, built evenly up to a hotel
+func buildable(game *domain.GameState, player *domain.PlayerState) []string {
+	return ownedProperties(game, player.UserID, func(tile domain.Tile) bool {
+		if tile.Type != "PROPERTY" || tile.GroupIdentifier == "" || tile.BuildingCount >= 5 {
+			return false
+		}
+		cost := tile.HouseCost
+		if tile.BuildingCount == 4 {
+			cost = tile.HotelCost
+		}
+		if cost > player.Balance {
+			return false
+		}
+		for _, t := range game.Board {
+			if t.GroupIdentifier != tile.GroupIdentifier {
+				continue
+			}
+			if t.OwnerID == nil || *t.OwnerID != player.UserID || t.IsMortgaged || t.BuildingCount < tile.BuildingCount {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// sellableBuildings lists where a player can sell a building: evenly, from the most built
+// properties of each group down
+func sellableBuildings(game *domain.GameState, userID string) []string {
+	most := make(map[string]int)
+	for _, t := range game.Board {
+		if t.GroupIdentifier != "" && t.BuildingCount > most[t.GroupIdentifier] {
+			most[t.GroupIdentifier] = t.BuildingCount
+		}
+	}
+	return ownedProperties(game, userID, func(t domain.Tile) bool {
+		return t.BuildingCount > 0 && t.BuildingCount == most[t.GroupIdentifier]
+	})
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// legalPayload is the payload a client would send for a listed action, as the LLM tools build
+// it. Trades ask a player with cash for $1.
+func legalPayload(game *domain.GameState, a domain.LegalAction) json.RawMessage {
+	payload := map[string]any{}
+	if len(a.PropertyIDs) > 0 {
+		payload["property_id"] = a.PropertyIDs[0]
+	}
+	if a.MaxAmount > 0 {
+		payload["amount"] = a.MinAmount
+	}
+	if len(a.Options) > 0 {
+		key := "option"
+		if a.Action == "USE_ITEM" {
+			key = "item"
+		}
+		payload[key] = a.Options[0]
+	}
+	if len(a.TradeIDs) > 0 {
+		payload["trade_id"] = a.TradeIDs[0]
+	}
+	for _, p := range game.Players {
+		if slices.Contains(a.TargetIDs, p.UserID) && p.Balance > 0 {
+			payload["target_id"] = p.UserID
+			break
+		}
+	}
+	if a.Action == "INITIATE_TRADE" || a.Action == "COUNTER_TRADE" {
+		payload["request_cash"] = 1
+	}
+	data, _ := json.Marshal(payload)
+	return data
+}
+
+// goesThrough dispatches an action on a copy of a game, guarded like a live one, and returns
+// why it was refused, or "" when it changed the game
+func goesThrough(sim *Simulator, game *domain.GameState, userID string, a domain.LegalAction) string {
+	clone := cloneGame(game)
+	state := func() string {
+		logs, chat, last := clone.Logs, clone.ChatMessages, clone.LastAction
+		clone.Logs, clone.ChatMessages, clone.LastAction = nil, nil, ""
+		data, _ := json.Marshal(clone)
+		clone.Logs, clone.ChatMessages, clone.LastAction = logs, chat, last
+		return string(data)
+	}
+	before, logged := state(), len(clone.Logs)
+
+	sim.rules.guardAction(clone, a.Action, func() {
+		sim.rules.dispatch(clone, userID, a.Action, legalPayload(clone, a))
+	})
+	if state() != before {
+		return ""
+	}
+	var alerts []string
+	for _, entry := range clone.Logs[min(logged, len(clone.Logs)):] {
+		if entry.Type == "ALERT" {
+			alerts = append(alerts, entry.Message)
+		}
+	}
+	return "no effect " + strings.Join(alerts, "; ")
+}
+
+// checkLegalActions fails the test for every action listed to a player that doesn't go through
+func checkLegalActions(t *testing.T, sim *Simulator, game *domain.GameState, state string) []string {
+	t.Helper()
+	var listed []string
+	for _, p := range game.Players {
+		for _, a := range sim.rules.LegalActions(game, p.UserID) {
+			if problem := goesThrough(sim, game, p.UserID, a); problem != "" {
+				t.Errorf("%s: %s listed for %s but refused: %s", state, a.Action, p.UserID, problem)
+			}
+			listed = append(listed, a.Action)
+		}
+	}
+	return listed
+}
+
+func TestLegalActions_GoThrough(t *testing.T) {
+	sim, game := simulatedGame()
+
+	checked := 0
+	for i := 0; i < 120 && sim.Step(game); i++ {
+		checked += len(checkLegalActions(t, sim, game, "step"))
+		if t.Failed() {
+			t.Fatalf("at step %d", i)
+		}
+	}
+	if checked == 0 {
+		t.Fatal("no legal actions were listed")
+	}
+}
+
+func TestLegalActions_GoThroughInEveryPhase(t *testing.T) {
+	states := make(map[string]*domain.GameState)
+	sim, game := simulatedLobby()
+	states["waiting"] = cloneGame(game)
+	sim.rules.handleStartGame(game, "BOT_A", nil)
+	states["rolling order"] = cloneGame(game)
+
+	for i := 0; i < 400 && sim.Step(game) && len(states) < 4; i++ {
+		player := sim.rules.getPlayer(game, game.CurrentTurnID)
+		if game.Status != domain.GameStatusActive || player == nil || game.ActiveAuction != nil || player.Balance < 0 {
+			continue
+		}
+		actions := sim.rules.LegalActions(game, player.UserID)
+		switch {
+		case slices.ContainsFunc(actions, func(a domain.LegalAction) bool { return a.Action == "ROLL_DICE" }):
+			if states["rolling"] == nil {
+				states["rolling"] = cloneGame(game)
+			}
+		case slices.ContainsFunc(actions, func(a domain.LegalAction) bool { return a.Action == "START_AUCTION" }):
+			if states["landed"] == nil {
+				states["landed"] = cloneGame(game)
+			}
+		}
+	}
+	if states["rolling"] == nil || states["landed"] == nil {
+		t.Fatalf("the game never reached every phase: %v", slices.Collect(maps.Keys(states)))
+	}
+
+	auction := cloneGame(states["landed"])
+	tile := auction.Board[sim.rules.getPlayer(auction, auction.CurrentTurnID).Position]
+	sim.rules.dispatch(auction, auction.CurrentTurnID, "START_AUCTION", json.RawMessage(`{"property_id": "`+tile.PropertyID+`"}`))
+	states["auction"] = auction
+
+	dutch := cloneGame(states["landed"])
+	dutch.Settings.AuctionFormat = domain.AuctionDutch
+	sim.rules.dispatch(dutch, dutch.CurrentTurnID, "START_AUCTION", json.RawMessage(`{"property_id": "`+tile.PropertyID+`"}`))
+	if dutch.ActiveAuction == nil || dutch.ActiveAuction.Format != domain.AuctionDutch {
+		t.Fatal("the Dutch auction didn't start")
+	}
+	// The only bid is the clock's price, which the player can't lower
+	for _, a := range sim.rules.LegalActions(dutch, dutch.Players[0].UserID) {
+		if price := dutchPrice(dutch.ActiveAuction, time.Now()); a.Action == "BID" && (a.MinAmount != price || a.MaxAmount != price) {
+			t.Errorf("Dutch bid listed from $%d to $%d at a price of $%d", a.MinAmount, a.MaxAmount, price)
+		}
+	}
+	states["dutch auction"] = dutch
+
+	debt := cloneGame(states["rolling"])
+	debtor := sim.rules.getPlayer(debt, debt.CurrentTurnID)
+	sim.rules.bankCollect(debt, debtor, debtor.Balance+100, domain.FlowTax, "prueba")
+	states["debt"] = debt
+
+	trade := cloneGame(states["rolling"])
+	sim.rules.dispatch(trade, "BOT_A", "INITIATE_TRADE", json.RawMessage(`{"target_id": "BOT_B", "offer_cash": 10}`))
+	states["trade"] = trade
+
+	// Each phase lists what it is about, and everything listed goes through
+	for state, want := range map[string]string{
+		"waiting":       "START_GAME",
+		"rolling order": "ROLL_ORDER",
+		"rolling":       "ROLL_DICE",
+		"landed":        "BUY_PROPERTY",
+		"auction":       "BID",
+		"dutch auction": "BID",
+		"debt":          "DECLARE_BANKRUPTCY",
+		"trade":         "ACCEPT_TRADE",
+	} {
+		if listed := checkLegalActions(t, sim, states[state], state); !slices.Contains(listed, want) {
+			t.Errorf("%s: %s not listed in %v", state, want, listed)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// savingsRate is the deposit interest per round: a spread below the central bank's
+// base rate when the economy model is on, the fixed SavingsRate otherwise
+func savingsRate(game *domain.GameState) int {
+	if game.Economy == nil {
+		return domain.SavingsRate
+	}
+	rate := game.Economy.BaseRate - 2
+	if rate < 1 {
+		rate = 1
+	}
+	return rate
+}
+
+func (s *GameService) handleDepositSavings(game *domain.GameState, userID string, payload json.RawMessage) {
+	var req struct {
+		Amount int `json:"amount"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil || req.Amount <= 0 {
+		return
+	}
+
+	player := s.getPlayer(game, userID)
+	if player == nil || !player.IsActive {
+		return
+	}
+	if player.Balance < req.Amount {
+		s.addLog(game, "Fondos insuficientes para depositar $"+strconv.Itoa(req.Amount), "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+
+	s.initCreditProfile(player)
+	s.transfer(game, userID, savingsAccount(userID), req.Amount, domain.ReasonSavingsDeposit, "")
+	// Every deposit restarts the lock period for the whole account
+	player.SavingsLockRound = player.Credit.CurrentRound + domain.SavingsLockRounds
+
+	s.addLog(game, player.Name+" depositó $"+strconv.Itoa(req.Amount)+" en su cuenta de ahorro ("+strconv.Itoa(savingsRate(game))+"% por ronda)", "ACTION")
+	s.broadcastGameState(game)
+}
+
+func (s *GameService) handleWithdrawSavings(game *domain.GameState, userID string, payload json.RawMessage) {
+	var req struct {
+		Amount int `json:"amount"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil || req.Amount <= 0 {
+		return
+	}
+
+	player := s.getPlayer(game, userID)
+	if player == nil {
+		return
+	}
+	if player.Savings < req.Amount {
+		s.addLog(game, "No tienes $"+strconv.Itoa(req.Amount)+" en tu cuenta de ahorro", "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+
+	s.initCreditProfile(player)
+	penalty := 0
+	if player.Credit.CurrentRound < player.SavingsLockRound {
+		penalty = req.Amount * domain.EarlyWithdrawalPenaltyPct / 100
+	}
+
+	s.transfer(game, savingsAccount(userID), userID, req.Amount, domain.ReasonSavingsWithdrawal, "")
+	s.collectFine(game, player, penalty, domain.FlowFine, "Retiro anticipado de ahorros")
+
+	msg := player.Name + " retiró $" + strconv.Itoa(req.Amount) + " de su cuenta de ahorro"
+	if penalty > 0 {
+		msg += " (penalización por retiro anticipado: $" + strconv.Itoa(penalty) + ")"
+	}
+	s.addLog(game, msg, "ACTION")
+	s.broadcastGameState(game)
+}
+
+// accrueSavingsInterest pays a round of deposit interest, called when the player passes GO.
+// Returns the message fragment to append to the pass-GO log.
+func (s *GameService) accrueSavingsInterest(game *domain.GameState, player *domain.PlayerState) string {
+	if player.Savings <= 0 {
+		return ""
+	}
+	interest := player.Savings * savingsRate(game) / 100
+	if interest <= 0 {
+		return ""
+	}
+	s.transfer(game, domain.AccountBank, savingsAccount(player.UserID), interest, domain.FlowSavingsInterest, "")
+	return " Intereses de ahorro: +$" + strconv.Itoa(interest) + "."
+}
+
+// netWorth values everything a player owns minus what they owe: cash, savings, properties
+// (net of mortgages), buildings at their resale value and shares at market price
+func (s *GameService) netWorth(game *domain.GameState, player *domain.PlayerState) int {
+	worth := player.Balance + player.Savings - player.Loan
+
+	for _, t := range game.Board {
+		if t.PropertyID == "" || game.PropertyOwnership[t.PropertyID] != player.UserID {
+			continue
+		}
+		if t.IsMortgaged {
+			mortgage := t.MortgageValue
+			if mortgage == 0 {
+				mortgage = t.Price / 2
+			}
+			worth += t.Price - mortgage
+		} else {
+			worth += t.Price
+		}
+		if t.BuildingCount == 5 {
+			worth += (4*t.HouseCost + t.HotelCost) / 2
+		} else {
+			worth += t.BuildingCount * t.HouseCost / 2
+		}
+	}
+
+	if game.StockMarket != nil {
+		for _, stock := range game.StockMarket.Stocks {
+			worth += stock.Holdings[player.UserID] * stock.Price
+		}
+	}
+	return worth
+}
+
+// computeStandings ranks players by net worth, bankrupt players last
+func (s *GameService) computeStandings(game *domain.GameState) []domain.Standing {
+	standings := make([]domain.Standing, 0, len(game.Players))
+	for _, p := range game.Players {
+		standings = append(standings, domain.Standing{
+			UserID:   p.UserID,
+			Name:     p.Name,
+			NetWorth: s.netWorth(game, p),
+			Bankrupt: !p.IsActive,
+		})
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Bankrupt != standings[j].Bankrupt {
+			return !standings[i].Bankrupt
+		}
+		return standings[i].NetWorth > standings[j].NetWorth
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+	return standings
+}
+
+// finishGame closes the game and records the final standings
+func (s *GameService) finishGame(game *domain.GameState) {
+	if game.Status == domain.GameStatusFinished {
+		return
+	}
+	game.Status = domain.GameStatusFinished
+	game.Standings = s.computeStandings(game)
+	if len(game.Standings) > 0 {
+		winner := game.Standings[0]
+		s.addLog(game, "🏆 ¡"+winner.Name+" gana la partida con un patrimonio de $"+strconv.Itoa(winner.NetWorth)+"!", "SUCCESS")
+	}
+}
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/boards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/bots"
+	"github.com/gabriel3312cl/finances-game/backend/internal/cards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// maxTurnMoves caps the decisions of a simulated turn: a strategy insisting on a move the rules
+// reject has its turn ended for it
+const maxTurnMoves = 40
+
+// Simulator plays games with the rules alone: no database, websocket hub, LLM or bot delays.
+// Bots look ahead with it (it implements bots.Rules) and tools run whole games on it. Players
+// follow the heuristic strategy unless given another one. A Simulator isn't safe for concurrent
+// use.
+type Simulator struct {
+	rules      *GameService
+	bots       *BotService
+	strategies map[string]bots.Strategy // UserID -> strategy other than the heuristic one
+	lookahead  *Simulator               // Rules handed to those strategies
+	turn       string                   // Player whose turn moves counts
+	moves      int
+}
+
+// NewSimulator returns a simulator for a board definition and the cards of its deck, for tools
+// that play games without a server. The board must be valid; invalid cards are left out.
+func NewSimulator(board domain.BoardDefinition, deck []domain.Card) (*Simulator, error) {
+	if err := checkBoard(&board); err != nil {
+		return nil, err
+	}
+	tiles := boards.Build(&board)
+	var valid []domain.Card
+	for _, c := range deck {
+		if c.Enabled && cards.Validate(c, tiles) == nil {
+			valid = append(valid, c)
+		}
+	}
+	return newSimulator(map[int]*domain.BoardDefinition{0: &board}, map[int][]domain.Card{0: valid}, 0), nil
+}
+
+// Simulator returns a simulator with the boards and card decks of this service
+func (s *GameService) Simulator() *Simulator {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return newSimulator(maps.Clone(s.boards), maps.Clone(s.cardDecks), s.defaultCardDeck)
+}
+
+func newSimulator(boards map[int]*domain.BoardDefinition, decks map[int][]domain.Card, defaultDeck int) *Simulator {
+	rules := &GameService{
+		games:           make(map[string]*domain.GameState),
+		boards:          boards,
+		active:          make(map[string]bool),
+		cardDecks:       decks,
+		defaultCardDeck: defaultDeck,
+	}
+	return &Simulator{rules: rules, bots: &BotService{gameService: rules}}
+}
+
+// Clone copies a game for lookahead, without its history: logs, chat, closed trades and the
+// ledger, whose invariant checks are for real games
+func (sim *Simulator) Clone(game *domain.GameState) *domain.GameState {
+	clone := cloneGame(game)
+	if clone == nil {
+		return nil
+	}
+	clone.Logs = nil
+	clone.ChatMessages = nil
+	clone.TradeHistory = nil
+	clone.Ledger = nil
+	shuffleDecks(clone)
+	return clone
+}
+
+// botView is the copy of a game a bot decides on. It holds no more than the bot could see at
+// the table: the other envelopes of a sealed auction are left out and the decks reshuffled.
+func botView(game *domain.GameState, userID string) *domain.GameState {
+	view := cloneGame(game)
+	if view == nil {
+		return nil
+	}
+	if auction := view.ActiveAuction; auction != nil && isSealedAuction(auction) {
+		maps.DeleteFunc(auction.SealedBids, func(id string, _ int) bool { return id != userID })
+		auction.Bids = slices.DeleteFunc(auction.Bids, func(b domain.AuctionBid) bool { return b.PlayerID != userID })
+	}
+	shuffleDecks(view)
+	return view
+}
+
+// shuffleDecks reorders the cards left in each deck: a copy knows which cards remain, not in
+// what order they come
+func shuffleDecks(game *domain.GameState) {
+	for _, ids := range game.CardDecks {
+		rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	}
+}
+
+// cloneGame deep-copies a game through its JSON form, like the snapshots of guardAction
+func cloneGame(game *domain.GameState) *domain.GameState {
+	data, err := json.Marshal(game)
+	if err != nil {
+		log.Printf("Error copying game %s: %v", game.GameID, err)
+		return nil
+	}
+	var clone domain.GameState
+	if err := json.Unmarshal(data, &clone); err != nil {
+		log.Printf("Error copying game %s: %v", game.GameID, err)
+		return nil
+	}
+	restoreMaps(&clone)
+	return &clone
+}
+
+// Apply plays an action for a player through the same dispatcher as a live game
+func (sim *Simulator) Apply(game *domain.GameState, userID string, action *domain.BotAction) {
+	player := sim.rules.getPlayer(game, userID)
+	if player == nil || action == nil || botDeniedActions[action.Action] {
+		return
+	}
+	payload := botPayload(game, player, action)
+	sim.rules.guardAction(game, action.Action, func() {
+		sim.rules.dispatch(game, userID, action.Action, payload)
+	})
+}
+
+// LegalActions lists what a player can do in a game
+func (sim *Simulator) LegalActions(game *domain.GameState, userID string) []domain.LegalAction {
+	return sim.rules.LegalActions(game, userID)
+}
+
+// Default is the heuristic strategy's action for a player
+func (sim *Simulator) Default(game *domain.GameState, userID string) *domain.BotAction {
+	player := sim.rules.getPlayer(game, userID)
+	if player == nil {
+		return nil
+	}
+	return sim.decide(game, player)
+}
+
+func (sim *Simulator) decide(game *domain.GameState, player *domain.PlayerState) *domain.BotAction {
+	view := bots.View{Game: game, Bot: player, Profile: domain.GetBotProfile(player.BotPersonalityID)}
+	if strategy, ok := sim.strategies[player.UserID]; ok {
+		// Strategies search on what their seat could see, like live bots
+		if view.Game = botView(game, player.UserID); view.Game == nil {
+			return nil
+		}
+		view.Bot = sim.rules.getPlayer(view.Game, player.UserID)
+		if sim.lookahead == nil {
+			sim.lookahead = newSimulator(sim.rules.boards, sim.rules.cardDecks, sim.rules.defaultCardDeck)
+		}
+		view.Rules = sim.lookahead
+		return strategy.Decide(view)
+	}
+	return sim.bots.heuristicDecision(view)
+}
+
+// SetStrategy makes a player follow a strategy instead of the heuristic one. Lookahead copies
+// of the game are always played with the heuristic strategy.
+func (sim *Simulator) SetStrategy(userID string, strategy bots.Strategy) {
+	if sim.strategies == nil {
+		sim.strategies = make(map[string]bots.Strategy)
+	}
+	sim.strategies[userID] = strategy
+}
+
+// NewGame seats a bot per personality, BOT_1, BOT_2... in order, and starts a game on the
+// simulator's board with the starting cash and house rules given. Seats roll for the turn
+// order on the first steps.
+func (sim *Simulator) NewGame(seats []domain.BotProfile, initialBalance int, settings domain.GameSettings) *domain.GameState {
+	board := sim.rules.boards[0]
+	game := &domain.GameState{
+		GameID:            "SIM",
+		Status:            domain.GameStatusWaiting,
+		Board:             boards.Build(board),
+		PropertyOwnership: make(map[string]string),
+		TileVisits:        make(map[int]int),
+		TurnOrder:         []string{},
+	}
+	for i, profile := range seats {
+		game.Players = append(game.Players, &domain.PlayerState{
+			UserID:           fmt.Sprintf("BOT_%d", i+1),
+			Name:             fmt.Sprintf("%s #%d", profile.Name, i+1),
+			IsBot:            true,
+			IsActive:         true,
+			BotPersonalityID: profile.ID,
+		})
+	}
+	game.HostID = game.Players[0].UserID
+
+	payload, _ := json.Marshal(map[string]any{"initial_balance": initialBalance, "settings": settings})
+	sim.rules.handleStartGame(game, game.HostID, payload)
+	return game
+}
+
+// Finish closes a game on its net worth standings, for games stopped before a single player
+// is left
+func (sim *Simulator) Finish(game *domain.GameState) {
+	sim.rules.finishGame(game)
+}
+
+// Playout plays a game on for a number of rounds, or until it's over
+func (sim *Simulator) Playout(game *domain.GameState, rounds int) {
+	end := game.Round + rounds
+	// Every round takes a few decisions per player; the cap only stops a game going nowhere
+	for steps := rounds * (len(game.Players) + 1) * maxTurnMoves; steps > 0 && game.Round < end; steps-- {
+		if !sim.Step(game) {
+			return
+		}
+	}
+}
+
+// Score is a player's share of the net worth of the players still in the game: 0 once bankrupt,
+// 1 for the winner of a finished game
+func (sim *Simulator) Score(game *domain.GameState, userID string) float64 {
+	player := sim.rules.getPlayer(game, userID)
+	if player == nil || !player.IsActive {
+		return 0
+	}
+	if game.Status == domain.GameStatusFinished {
+		if len(game.Standings) > 0 && game.Standings[0].UserID == userID {
+			return 1
+		}
+		return 0
+	}
+	mine, total := 0, 0
+	for _, p := range game.Players {
+		if !p.IsActive {
+			continue
+		}
+		worth := max(sim.rules.netWorth(game, p), 0)
+		total += worth
+		if p.UserID == userID {
+			mine = worth
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(mine) / float64(total)
+}
+
+// Step plays the next decision of a game, in the order a live table takes them: turn order
+// rolls, auction bids, answers to trades, players in debt raising cash, then the player whose
+// turn it is. It reports false once the game is over or can't go on.
+func (sim *Simulator) Step(game *domain.GameState) bool {
+	switch game.Status {
+	case domain.GameStatusRollingOrder:
+		for _, p := range game.Players {
+			if _, rolled := game.OrderRolls[p.UserID]; !rolled {
+				sim.rules.handleRollOrder(game, p.UserID)
+				return true
+			}
+		}
+		return false
+	case domain.GameStatusActive:
+	default:
+		return false
+	}
+
+	if game.ActiveAuction != nil && game.ActiveAuction.IsActive {
+		sim.stepAuction(game)
+		return true
+	}
+
+	for _, p := range game.Players {
+		if !p.IsActive {
+			continue
+		}
+		if incoming := incomingTrades(game, p.UserID); len(incoming) > 0 {
+			ref := json.RawMessage(`{"trade_id": "` + incoming[0].ID + `"}`)
+			reply := "REJECT_TRADE"
+			if sim.bots.acceptsTrade(game, p, incoming[0]) {
+				reply = "ACCEPT_TRADE"
+			}
+			userID := p.UserID
+			sim.rules.guardAction(game, reply, func() {
+				sim.rules.dispatch(game, userID, reply, ref)
+			})
+			return true
+		}
+	}
+
+	for _, p := range game.Players {
+		if !p.IsActive || p.Balance >= 0 || p.UserID == game.CurrentTurnID {
+			continue
+		}
+		userID := p.UserID
+		for i := 0; i < maxTurnMoves && p.IsActive && p.Balance < 0; i++ {
+			sim.Apply(game, userID, sim.decide(game, p))
+			p = sim.rules.getPlayer(game, userID) // A rolled back action replaces the players
+		}
+		if p.IsActive && p.Balance < 0 {
+			// Nothing left to raise cash with
+			sim.rules.handleDeclareBankruptcy(game, userID)
+		}
+		return game.Status == domain.GameStatusActive
+	}
+
+	player := sim.rules.getPlayer(game, game.CurrentTurnID)
+	if player == nil {
+		return false
+	}
+	if sim.turn != player.UserID {
+		sim.turn, sim.moves = player.UserID, 0
+	}
+	sim.moves++
+
+	action := sim.decide(game, player)
+	if action != nil && action.Action == "DRAW_CARD" && len(sim.rules.cardDeck(game, game.Board[player.Position].Type)) == 0 {
+		action = nil // No cards to draw without a deck
+	}
+	switch {
+	case sim.moves > maxTurnMoves && game.Dice[0] == 0:
+		action = &domain.BotAction{Action: "ROLL_DICE"}
+	case sim.moves > maxTurnMoves || action == nil:
+		action = &domain.BotAction{Action: "END_TURN"}
+	}
+	sim.Apply(game, player.UserID, action)
+	return game.Status == domain.GameStatusActive
+}
+
+// stepAuction lets the next bidder act, or closes the auction once nobody will bid more. On a
+// Dutch clock, the simulated time runs one drop forward when nobody takes the price.
+func (sim *Simulator) stepAuction(game *domain.GameState) {
+	auction := game.ActiveAuction
+	dutch := auctionFormat(auction) == domain.AuctionDutch
+
+	for _, p := range game.Players {
+		if !p.IsActive || p.UserID == auction.BidderID || auction.PassedPlayers[p.UserID] {
+			continue
+		}
+		if _, sealed := auction.SealedBids[p.UserID]; sealed {
+			continue
+		}
+		var action *domain.BotAction
+		if _, custom := sim.strategies[p.UserID]; custom {
+			action = sim.decide(game, p)
+		} else {
+			action = sim.bots.auctionDecision(game, p)
+		}
+		if action == nil || action.Action != "BID" {
+			if dutch {
+				continue // Waits for a lower price
+			}
+			action = &domain.BotAction{Action: "PASS_AUCTION"}
+		}
+		bids := len(auction.Bids)
+		sim.Apply(game, p.UserID, action)
+		if auction.IsActive && len(auction.Bids) == bids && action.Action == "BID" {
+			// The rules turned the bid down
+			sim.rules.handlePassAuction(game, p.UserID)
+		}
+		return
+	}
+
+	if dutch && dutchPrice(auction, time.Now()) > auction.FloorPrice {
+		auction.StartTime -= int64(domain.DutchDropInterval / time.Second)
+		return
+	}
+	sim.rules.endAuction(game)
+}
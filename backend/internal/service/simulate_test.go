@@ -0,0 +1,95 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/boards"
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func simulatedGame() (*Simulator, *domain.GameState) {
+	sim, game := simulatedLobby()
+	sim.rules.handleStartGame(game, "BOT_A", nil)
+	return sim, game
+}
+
+// simulatedLobby seats three bots at a small board, waiting for the host to start
+func simulatedLobby() (*Simulator, *domain.GameState) {
+	avenue := func(slug, group string, price int) domain.BoardTile {
+		return domain.BoardTile{Type: "PROPERTY", Property: &domain.Property{
+			Slug: slug, Name: slug, GroupID: group, Price: price, RentBase: price / 10,
+			Rent1House: price / 2, Rent2House: price, Rent3House: price * 2, Rent4House: price * 3, RentHotel: price * 4,
+			HouseCost: price / 2, HotelCost: price / 2, MortgageValue: price / 2, UnmortgageValue: price * 11 / 20,
+		}}
+	}
+	def := &domain.BoardDefinition{
+		Name:    "Barrio",
+		Size:    12,
+		Corners: domain.BoardCorners{Go: 0, Jail: 3, FreeParking: 6, GoToJail: 9},
+		Groups:  []domain.PropertyGroup{{ID: "g1", Name: "Centro"}, {ID: "g2", Name: "Puerto"}},
+		Tiles: []domain.BoardTile{
+			{Type: "CORNER"}, avenue("a", "g1", 100), avenue("b", "g1", 120), {Type: "CORNER"},
+			avenue("c", "g2", 200), avenue("d", "g2", 220), {Type: "CORNER"}, {Type: domain.TileLuxuryTax},
+			avenue("e", "g2", 240), {Type: "CORNER"}, avenue("f", "g1", 140), {Type: domain.TileLuxuryTax},
+		},
+	}
+	normalizeBoard(def)
+
+	sim := newSimulator(map[int]*domain.BoardDefinition{0: def}, nil, 0)
+	game := &domain.GameState{
+		GameID:            "SIM",
+		Status:            domain.GameStatusWaiting,
+		Board:             boards.Build(def),
+		PropertyOwnership: make(map[string]string),
+		TileVisits:        make(map[int]int),
+	}
+	for _, id := range []string{"BOT_A", "BOT_B", "BOT_C"} {
+		game.Players = append(game.Players, &domain.PlayerState{UserID: id, Name: id, IsBot: true, IsActive: true, BotPersonalityID: "classic"})
+	}
+	return sim, game
+}
+
+func TestSimulator_PlaysWithoutIO(t *testing.T) {
+	sim, game := simulatedGame()
+
+	for i := 0; i < 600 && sim.Step(game); i++ {
+		if err := sim.rules.checkInvariants(game); err != nil {
+			t.Fatalf("step %d broke invariants: %v", i, err)
+		}
+	}
+	if game.Round < 5 && game.Status != domain.GameStatusFinished {
+		t.Fatalf("game stalled in round %d (%s)", game.Round, game.LastAction)
+	}
+	if len(game.PropertyOwnership) == 0 {
+		t.Error("nobody bought anything")
+	}
+
+	// Lookahead copies are independent of the game
+	clone := sim.Clone(game)
+	sim.Playout(clone, 3)
+	total := 0.0
+	for _, p := range clone.Players {
+		total += sim.Score(clone, p.UserID)
+	}
+	if clone.Ledger != nil || total < 0.99 || total > 1.01 {
+		t.Errorf("scores of a copy add up to %.2f", total)
+	}
+}
+
+func TestSimulator_TryReportsRejections(t *testing.T) {
+	sim, game := simulatedGame()
+	for game.CurrentTurnID == "" && sim.Step(game) {
+	}
+	player := game.CurrentTurnID
+	balance := sim.rules.getPlayer(game, player).Balance
+
+	if problem := sim.Try(game, player, &domain.BotAction{Action: "PAY_BAIL"}); problem == "" {
+		t.Error("paying bail outside jail must be rejected")
+	}
+	if problem := sim.Try(game, player, &domain.BotAction{Action: "ROLL_DICE"}); problem != "" {
+		t.Errorf("rolling on one's turn was rejected: %s", problem)
+	}
+	if game.Dice[0] != 0 || sim.rules.getPlayer(game, player).Balance != balance {
+		t.Error("trying an action must not touch the game")
+	}
+}
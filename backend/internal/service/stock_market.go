@@ -0,0 +1,441 @@
+package service
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// bankHolderID identifies the bank as counterparty in share transfers
+const bankHolderID = "BANK"
+
+// initStockMarket issues SharesPerGroup shares for every color group on the board, all held by the bank
+func (s *GameService) initStockMarket(game *domain.GameState) {
+	market := &domain.StockMarket{
+		Stocks: make(map[string]*domain.GroupStock),
+		Orders: []*domain.ShareOrder{},
+	}
+
+	for _, t := range game.Board {
+		if t.GroupIdentifier == "" || t.Price <= 0 {
+			continue
+		}
+		if _, exists := market.Stocks[t.GroupIdentifier]; exists {
+			continue
+		}
+		market.Stocks[t.GroupIdentifier] = &domain.GroupStock{
+			GroupID:     t.GroupIdentifier,
+			GroupName:   t.GroupName,
+			GroupColor:  t.GroupColor,
+			TotalShares: domain.SharesPerGroup,
+			BankShares:  domain.SharesPerGroup,
+			Holdings:    make(map[string]int),
+		}
+	}
+
+	game.StockMarket = market
+	for groupID := range market.Stocks {
+		s.updateSharePrice(game, groupID)
+	}
+	s.addLog(game, "Bolsa de valores abierta: "+strconv.Itoa(len(market.Stocks))+" grupos cotizan acciones", "INFO")
+}
+
+// computeSharePrice values a group from its land, its buildings and the rent it has produced
+func (s *GameService) computeSharePrice(game *domain.GameState, stock *domain.GroupStock) int {
+	value := stock.RentIncome
+	for _, t := range game.Board {
+		if t.GroupIdentifier != stock.GroupID {
+			continue
+		}
+		value += t.Price
+		if t.BuildingCount == 5 {
+			value += 4*t.HouseCost + t.HotelCost
+		} else {
+			value += t.BuildingCount * t.HouseCost
+		}
+	}
+
+	price := value / stock.TotalShares
+	if price < 1 {
+		price = 1
+	}
+	return price
+}
+
+// updateSharePrice recalculates a group's quote and records the change in its price history
+func (s *GameService) updateSharePrice(game *domain.GameState, groupID string) {
+	if game.StockMarket == nil {
+		return
+	}
+	stock, ok := game.StockMarket.Stocks[groupID]
+	if !ok {
+		return
+	}
+
+	price := s.computeSharePrice(game, stock)
+	if price == stock.Price && len(stock.History) > 0 {
+		return
+	}
+	stock.Price = price
+
+	stock.History = append(stock.History, domain.SharePricePoint{Price: price, Timestamp: time.Now().Unix()})
+	if len(stock.History) > domain.MaxPriceHistoryLen {
+		stock.History = stock.History[len(stock.History)-domain.MaxPriceHistoryLen:]
+	}
+
+	if s.gameRepo != nil {
+		go func(gameID string) {
+			if err := s.gameRepo.SaveSharePrice(gameID, groupID, price); err != nil {
+				log.Printf("Error saving share price: %v", err)
+			}
+		}(game.GameID)
+	}
+}
+
+// payDividends distributes DividendPercent of a rent payment to the group's shareholders.
+// The share of the dividend that belongs to bank-held shares stays with the owner.
+// Returns the total amount deducted from the owner.
+func (s *GameService) payDividends(game *domain.GameState, tile *domain.Tile, rent int, owner *domain.PlayerState) int {
+	if game.StockMarket == nil || tile.GroupIdentifier == "" || rent <= 0 {
+		return 0
+	}
+	stock, ok := game.StockMarket.Stocks[tile.GroupIdentifier]
+	if !ok {
+		return 0
+	}
+
+	stock.RentIncome += rent
+	pool := rent * domain.DividendPercent / 100
+
+	paid := 0
+	for holderID, shares := range stock.Holdings {
+		if shares <= 0 {
+			continue
+		}
+		holder := s.getPlayer(game, holderID)
+		if holder == nil || !holder.IsActive {
+			continue
+		}
+		amount := pool * shares / stock.TotalShares
+		if amount <= 0 {
+			continue
+		}
+		s.transfer(game, owner.UserID, holderID, amount, domain.ReasonDividend, stock.GroupName)
+		paid += amount
+	}
+
+	if paid > 0 {
+		s.addLog(game, "Dividendos de "+stock.GroupName+": $"+strconv.Itoa(paid)+" repartidos a los accionistas", "INFO")
+	}
+	s.updateSharePrice(game, tile.GroupIdentifier)
+	return paid
+}
+
+// transferShares moves shares between holders (bankHolderID for the bank)
+func transferShares(stock *domain.GroupStock, fromID, toID string, quantity int) {
+	if fromID == bankHolderID {
+		stock.BankShares -= quantity
+	} else {
+		stock.Holdings[fromID] -= quantity
+		if stock.Holdings[fromID] <= 0 {
+			delete(stock.Holdings, fromID)
+		}
+	}
+	if toID == bankHolderID {
+		stock.BankShares += quantity
+	} else {
+		stock.Holdings[toID] += quantity
+	}
+}
+
+// committedShares counts the shares a player already offered in resting sell orders
+func committedShares(market *domain.StockMarket, groupID, userID string) int {
+	total := 0
+	for _, o := range market.Orders {
+		if o.GroupID == groupID && o.PlayerID == userID && o.Side == domain.OrderSideSell {
+			total += o.Quantity
+		}
+	}
+	return total
+}
+
+// committedCash is what a player already promised to pay in resting buy orders
+func committedCash(market *domain.StockMarket, userID string) int {
+	total := 0
+	for _, o := range market.Orders {
+		if o.PlayerID == userID && o.Side == domain.OrderSideBuy {
+			total += o.Quantity * o.LimitPrice
+		}
+	}
+	return total
+}
+
+// removeFilledOrders drops orders with no quantity left from the book
+func removeFilledOrders(market *domain.StockMarket) {
+	open := market.Orders[:0]
+	for _, o := range market.Orders {
+		if o.Quantity > 0 {
+			open = append(open, o)
+		}
+	}
+	market.Orders = open
+}
+
+type shareOrderRequest struct {
+	GroupID    string `json:"group_id"`
+	Quantity   int    `json:"quantity"`
+	LimitPrice int    `json:"limit_price"` // 0 = market order
+}
+
+// parseShareOrder validates a BUY_SHARES/SELL_SHARES payload against the game's market
+func (s *GameService) parseShareOrder(game *domain.GameState, payload json.RawMessage) (*shareOrderRequest, *domain.GroupStock) {
+	if game.StockMarket == nil {
+		s.addLog(game, "La bolsa de valores no está habilitada en esta partida.", "ALERT")
+		return nil, nil
+	}
+	if game.Status != domain.GameStatusActive {
+		return nil, nil
+	}
+
+	var req shareOrderRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, nil
+	}
+	if req.Quantity <= 0 || req.LimitPrice < 0 {
+		return nil, nil
+	}
+
+	stock, ok := game.StockMarket.Stocks[req.GroupID]
+	if !ok {
+		s.addLog(game, "Ese grupo no cotiza en la bolsa.", "ALERT")
+		return nil, nil
+	}
+	return &req, stock
+}
+
+func (s *GameService) handleBuyShares(game *domain.GameState, userID string, payload json.RawMessage) {
+	req, stock := s.parseShareOrder(game, payload)
+	if req == nil {
+		return
+	}
+	player := s.getPlayer(game, userID)
+	if player == nil || !player.IsActive {
+		return
+	}
+	market := game.StockMarket
+
+	// Asks from other players, cheapest (then oldest) first
+	var asks []*domain.ShareOrder
+	for _, o := range market.Orders {
+		if o.GroupID == req.GroupID && o.Side == domain.OrderSideSell && o.PlayerID != userID {
+			asks = append(asks, o)
+		}
+	}
+	sort.SliceStable(asks, func(i, j int) bool { return asks[i].LimitPrice < asks[j].LimitPrice })
+
+	remaining := req.Quantity
+	bought := 0
+	spent := 0
+	for remaining > 0 {
+		// Best source: a resting ask or the bank's float at the current quote
+		var ask *domain.ShareOrder
+		for _, a := range asks {
+			if a.Quantity > 0 {
+				ask = a
+				break
+			}
+		}
+		price := 0
+		fromBank := false
+		if ask != nil {
+			price = ask.LimitPrice
+		}
+		if stock.BankShares > 0 && (ask == nil || stock.Price < price) {
+			price = stock.Price
+			fromBank = true
+		}
+		if price == 0 || (req.LimitPrice > 0 && price > req.LimitPrice) {
+			break
+		}
+
+		available := stock.BankShares
+		if !fromBank {
+			available = ask.Quantity
+		}
+		qty := min(remaining, available, player.Balance/price)
+		if qty <= 0 {
+			break
+		}
+
+		cost := qty * price
+		if fromBank {
+			s.transfer(game, userID, domain.AccountBank, cost, domain.FlowShares, stock.GroupName)
+			transferShares(stock, bankHolderID, userID, qty)
+		} else {
+			seller := s.getPlayer(game, ask.PlayerID)
+			if seller == nil || stock.Holdings[ask.PlayerID] < qty {
+				// Stale order: seller no longer holds the shares
+				ask.Quantity = 0
+				continue
+			}
+			s.transfer(game, userID, ask.PlayerID, cost, domain.FlowShares, stock.GroupName)
+			transferShares(stock, ask.PlayerID, userID, qty)
+			ask.Quantity -= qty
+		}
+
+		remaining -= qty
+		bought += qty
+		spent += cost
+	}
+	removeFilledOrders(market)
+
+	if bought > 0 {
+		s.addLog(game, player.Name+" compró "+strconv.Itoa(bought)+" acciones de "+stock.GroupName+" por $"+strconv.Itoa(spent), "ACTION")
+	}
+
+	// Rest the remainder of a limit order in the book, if the cash not promised to other bids
+	// covers it; fills check the balance again
+	if remaining > 0 && req.LimitPrice > 0 {
+		if player.Balance-committedCash(market, userID) >= remaining*req.LimitPrice {
+			s.addShareOrder(game, player, req.GroupID, domain.OrderSideBuy, remaining, req.LimitPrice)
+		} else {
+			s.addLog(game, player.Name+" no tiene fondos para dejar una orden de compra de "+strconv.Itoa(remaining)+" acciones a $"+strconv.Itoa(req.LimitPrice), "ALERT")
+		}
+	} else if remaining > 0 && bought == 0 {
+		s.addLog(game, player.Name+" no pudo comprar acciones de "+stock.GroupName, "ALERT")
+	}
+
+	s.broadcastGameState(game)
+}
+
+func (s *GameService) handleSellShares(game *domain.GameState, userID string, payload json.RawMessage) {
+	req, stock := s.parseShareOrder(game, payload)
+	if req == nil {
+		return
+	}
+	player := s.getPlayer(game, userID)
+	if player == nil || !player.IsActive {
+		return
+	}
+	market := game.StockMarket
+
+	if stock.Holdings[userID]-committedShares(market, req.GroupID, userID) < req.Quantity {
+		s.addLog(game, "No tienes suficientes acciones libres de "+stock.GroupName, "ALERT")
+		return
+	}
+
+	// Bids from other players, highest (then oldest) first
+	var bids []*domain.ShareOrder
+	for _, o := range market.Orders {
+		if o.GroupID == req.GroupID && o.Side == domain.OrderSideBuy && o.PlayerID != userID {
+			bids = append(bids, o)
+		}
+	}
+	sort.SliceStable(bids, func(i, j int) bool { return bids[i].LimitPrice > bids[j].LimitPrice })
+
+	remaining := req.Quantity
+	sold := 0
+	earned := 0
+	for remaining > 0 {
+		// Best counterparty: a resting bid or the bank buying back at the current quote
+		var bid *domain.ShareOrder
+		for _, b := range bids {
+			if b.Quantity > 0 {
+				bid = b
+				break
+			}
+		}
+		price := stock.Price
+		toBank := true
+		if bid != nil && bid.LimitPrice > price {
+			price = bid.LimitPrice
+			toBank = false
+		}
+		if req.LimitPrice > 0 && price < req.LimitPrice {
+			break
+		}
+
+		qty := remaining
+		if !toBank {
+			buyer := s.getPlayer(game, bid.PlayerID)
+			if buyer == nil || !buyer.IsActive {
+				bid.Quantity = 0
+				continue
+			}
+			// The order was funded when placed, but the cash may have been spent since
+			qty = min(qty, bid.Quantity, max(buyer.Balance, 0)/price)
+			if qty <= 0 {
+				// Buyer can no longer afford the order
+				bid.Quantity = 0
+				continue
+			}
+			s.transfer(game, bid.PlayerID, userID, qty*price, domain.FlowShares, stock.GroupName)
+			transferShares(stock, userID, bid.PlayerID, qty)
+			bid.Quantity -= qty
+		} else {
+			s.transfer(game, domain.AccountBank, userID, qty*price, domain.FlowShares, stock.GroupName)
+			transferShares(stock, userID, bankHolderID, qty)
+		}
+
+		remaining -= qty
+		sold += qty
+		earned += qty * price
+	}
+	removeFilledOrders(market)
+
+	if sold > 0 {
+		s.addLog(game, player.Name+" vendió "+strconv.Itoa(sold)+" acciones de "+stock.GroupName+" por $"+strconv.Itoa(earned), "ACTION")
+	}
+	if remaining > 0 {
+		s.addShareOrder(game, player, req.GroupID, domain.OrderSideSell, remaining, req.LimitPrice)
+	}
+
+	s.broadcastGameState(game)
+}
+
+// addShareOrder rests a limit order in the order book
+func (s *GameService) addShareOrder(game *domain.GameState, player *domain.PlayerState, groupID, side string, quantity, limitPrice int) {
+	market := game.StockMarket
+	market.NextOrderID++
+	market.Orders = append(market.Orders, &domain.ShareOrder{
+		ID:         "ORD-" + strconv.Itoa(market.NextOrderID),
+		GroupID:    groupID,
+		PlayerID:   player.UserID,
+		PlayerName: player.Name,
+		Side:       side,
+		Quantity:   quantity,
+		LimitPrice: limitPrice,
+		Timestamp:  time.Now().Unix(),
+	})
+
+	verb := "compra"
+	if side == domain.OrderSideSell {
+		verb = "venta"
+	}
+	s.addLog(game, player.Name+" publicó una orden de "+verb+" de "+strconv.Itoa(quantity)+" acciones a $"+strconv.Itoa(limitPrice), "INFO")
+}
+
+func (s *GameService) handleCancelShareOrder(game *domain.GameState, userID string, payload json.RawMessage) {
+	if game.StockMarket == nil {
+		return
+	}
+	var req struct {
+		OrderID string `json:"order_id"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return
+	}
+
+	for _, o := range game.StockMarket.Orders {
+		if o.ID == req.OrderID && o.PlayerID == userID {
+			o.Quantity = 0
+			removeFilledOrders(game.StockMarket)
+			s.broadcastGameState(game)
+			return
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func TestStockMarket_DividendsAndFunding(t *testing.T) {
+	owner := "a"
+	game := &domain.GameState{
+		Status: domain.GameStatusActive,
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 500, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 500, IsActive: true},
+			{UserID: "c", Name: "C", Balance: 200, IsActive: true},
+		},
+		Board: []domain.Tile{
+			{PropertyID: "P1", Name: "Uno", Type: "PROPERTY", Price: 200, GroupIdentifier: "G1", GroupName: "Centro", OwnerID: &owner},
+		},
+		PropertyOwnership: map[string]string{"P1": "a"},
+	}
+	s := &GameService{}
+	s.initTreasury(game)
+	s.initLedger(game)
+	s.initStockMarket(game)
+	stock := game.StockMarket.Stocks["G1"]
+	transferShares(stock, bankHolderID, "b", domain.SharesPerGroup/2)
+
+	// Rent collected by hand pays dividends like automatic rent
+	game.PendingRent = &domain.PendingRent{TargetID: "c", CreditorID: "a", Amount: 100, PropertyID: "P1"}
+	s.handleCollectRent(game, "a")
+	if b := game.Players[1].Balance; b != 505 {
+		t.Errorf("half the shares must earn half the 10%% dividend: B has %d", b)
+	}
+
+	// A resting bid needs cash not already promised to other bids
+	s.handleBuyShares(game, "c", []byte(`{"group_id": "G1", "quantity": 60, "limit_price": 1}`))
+	s.handleBuyShares(game, "c", []byte(`{"group_id": "G1", "quantity": 60, "limit_price": 1}`))
+	if orders := game.StockMarket.Orders; len(orders) != 1 {
+		t.Errorf("%d bids rested for $%d of cash", len(orders), game.Players[2].Balance)
+	}
+
+	// Bankrupt players keep no say in the market
+	game.Players[1].IsActive = false
+	s.handleSellShares(game, "b", []byte(`{"group_id": "G1", "quantity": 1}`))
+	if stock.Holdings["b"] != domain.SharesPerGroup/2 {
+		t.Error("an inactive player sold shares")
+	}
+	if err := s.checkInvariants(game); err != nil {
+		t.Fatal(err)
+	}
+}
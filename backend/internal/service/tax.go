@@ -0,0 +1,255 @@
+package service
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// taxRules returns the game's tax configuration, falling back to the classic taxes
+func taxRules(game *domain.GameState) domain.TaxRules {
+	if game.Settings.Taxes == nil {
+		return domain.DefaultTaxRules()
+	}
+	return *game.Settings.Taxes
+}
+
+// chargeTax deducts a tax from the player, records it in their ledger and sends it to the
+// configured destination
+func (s *GameService) chargeTax(game *domain.GameState, player *domain.PlayerState, taxType string, amount int, detail string) {
+	if amount <= 0 {
+		return
+	}
+	player.TaxesPaid += amount
+	player.TaxLedger = append(player.TaxLedger, domain.TaxEntry{
+		Type:      taxType,
+		Amount:    amount,
+		Detail:    detail,
+		Round:     game.Round,
+		Timestamp: time.Now().Unix(),
+	})
+	if len(player.TaxLedger) > domain.MaxTaxLedgerLen {
+		player.TaxLedger = player.TaxLedger[len(player.TaxLedger)-domain.MaxTaxLedgerLen:]
+	}
+
+	s.collectToBank(game, player, amount, domain.FlowTax, taxType, taxRules(game).Destination == domain.TaxToFreeParking)
+}
+
+// handleIncomeTaxTile charges the income tax, or asks the player to choose when the
+// percentage option is enabled. Returns the fragment for the roll description.
+func (s *GameService) handleIncomeTaxTile(game *domain.GameState, player *domain.PlayerState) string {
+	rules := taxRules(game)
+	if rules.IncomeTaxPercent <= 0 {
+		s.chargeTax(game, player, domain.TaxIncome, rules.IncomeTaxFlat, "Tarifa fija")
+		s.addLog(game, player.Name+" pagó impuesto sobre la renta ($"+strconv.Itoa(rules.IncomeTaxFlat)+")", "ALERT")
+		return ". Pagó Impuesto sobre la Renta ($" + strconv.Itoa(rules.IncomeTaxFlat) + ")"
+	}
+
+	percent := s.netWorth(game, player) * rules.IncomeTaxPercent / 100
+	if percent < 0 {
+		percent = 0
+	}
+	game.PendingTax = &domain.PendingTax{
+		PlayerID:      player.UserID,
+		FlatAmount:    rules.IncomeTaxFlat,
+		PercentAmount: percent,
+	}
+	return ". Impuesto sobre la Renta: elige $" + strconv.Itoa(rules.IncomeTaxFlat) + " o " + strconv.Itoa(rules.IncomeTaxPercent) + "% del patrimonio ($" + strconv.Itoa(percent) + ")"
+}
+
+func (s *GameService) handlePayIncomeTax(game *domain.GameState, userID string, payload json.RawMessage) {
+	var req struct {
+		Option string `json:"option"` // FLAT, PERCENT
+	}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return
+	}
+	if game.PendingTax == nil || game.PendingTax.PlayerID != userID {
+		return
+	}
+	s.settlePendingTax(game, req.Option)
+	s.broadcastGameState(game)
+}
+
+// settlePendingTax charges the pending income tax with the chosen option (flat by default)
+func (s *GameService) settlePendingTax(game *domain.GameState, option string) {
+	pending := game.PendingTax
+	if pending == nil {
+		return
+	}
+	game.PendingTax = nil
+
+	player := s.getPlayer(game, pending.PlayerID)
+	if player == nil {
+		return
+	}
+
+	amount := pending.FlatAmount
+	detail := "Tarifa fija"
+	if option == domain.IncomeTaxPercent {
+		amount = pending.PercentAmount
+		detail = strconv.Itoa(taxRules(game).IncomeTaxPercent) + "% del patrimonio"
+	}
+	s.chargeTax(game, player, domain.TaxIncome, amount, detail)
+	s.addLog(game, player.Name+" pagó impuesto sobre la renta ($"+strconv.Itoa(amount)+", "+detail+")", "ALERT")
+}
+
+// chargeLuxuryTax charges the luxury tax tile. Returns the fragment for the roll description.
+func (s *GameService) chargeLuxuryTax(game *domain.GameState, player *domain.PlayerState) string {
+	amount := taxRules(game).LuxuryTax
+	s.chargeTax(game, player, domain.TaxLuxury, amount, "")
+	s.addLog(game, player.Name+" pagó impuesto de lujo ($"+strconv.Itoa(amount)+")", "ALERT")
+	return ". Pagó Impuesto de Lujo ($" + strconv.Itoa(amount) + ")"
+}
+
+// chargePropertyTaxes charges every active player for their buildings, once per round
+func (s *GameService) chargePropertyTaxes(game *domain.GameState) {
+	rules := taxRules(game)
+	if rules.PropertyTaxPerHouse <= 0 && rules.PropertyTaxPerHotel <= 0 {
+		return
+	}
+
+	for _, p := range game.Players {
+		if !p.IsActive {
+			continue
+		}
+		total := 0
+		for _, t := range game.Board {
+			if t.OwnerID == nil || *t.OwnerID != p.UserID {
+				continue
+			}
+			if t.BuildingCount == 5 {
+				total += rules.PropertyTaxPerHotel
+			} else {
+				total += t.BuildingCount * rules.PropertyTaxPerHouse
+			}
+		}
+		if total > 0 {
+			s.chargeTax(game, p, domain.TaxProperty, total, "Ronda "+strconv.Itoa(game.Round))
+			s.addLog(game, p.Name+" pagó $"+strconv.Itoa(total)+" de contribuciones por sus edificios", "ALERT")
+		}
+	}
+}
+
+// collectFreeParking hands the pot to the player landing on FREE_PARKING.
+// Returns the fragment for the roll description.
+func (s *GameService) collectFreeParking(game *domain.GameState, player *domain.PlayerState) string {
+	if game.FreeParkingPot <= 0 {
+		return ""
+	}
+	pot := game.FreeParkingPot
+	s.transfer(game, domain.AccountFreeParking, player.UserID, pot, domain.FlowJackpot, "")
+	s.addLog(game, "💰 "+player.Name+" se lleva el pozo de la Parada Libre: $"+strconv.Itoa(pot), "SUCCESS")
+	return ". ¡Ganó el pozo de $" + strconv.Itoa(pot) + "!"
+}
+
+// setCostBasis records what a player paid for a property
+func setCostBasis(game *domain.GameState, propertyID string, cost int) {
+	if game.CostBasis == nil {
+		game.CostBasis = make(map[string]int)
+	}
+	game.CostBasis[propertyID] = cost
+}
+
+// costBasis returns the recorded acquisition cost, defaulting to the list price
+func costBasis(game *domain.GameState, tile *domain.Tile) int {
+	if cost, ok := game.CostBasis[tile.PropertyID]; ok {
+		return cost
+	}
+	return tile.Price
+}
+
+// capitalGainsTax is the tax due on a realized profit, 0 when the rule is disabled
+func capitalGainsTax(game *domain.GameState, gain int) int {
+	percent := taxRules(game).CapitalGainsPercent
+	if percent <= 0 || gain <= 0 {
+		return 0
+	}
+	return gain * percent / 100
+}
+
+// chargeCapitalGains taxes a realized profit when the rule is enabled
+func (s *GameService) chargeCapitalGains(game *domain.GameState, player *domain.PlayerState, gain int, detail string) {
+	tax := capitalGainsTax(game, gain)
+	if tax <= 0 {
+		return
+	}
+	s.chargeTax(game, player, domain.TaxCapitalGains, tax, detail)
+	s.addLog(game, player.Name+" pagó $"+strconv.Itoa(tax)+" de impuesto a la ganancia de capital ("+detail+")", "ALERT")
+}
+
+// tradeGain is the profit a participant of a trade realizes on the properties they give away
+type tradeGain struct {
+	playerID string
+	gain     int
+}
+
+// applyTradeCapitalGains taxes each participant of a trade on the properties they give away
+// and records the cost basis of the properties they receive. Must run before ownership changes.
+func (s *GameService) applyTradeCapitalGains(game *domain.GameState, legs []domain.TradeLeg) {
+	gains, newBasis := s.tradeCapitalGains(game, legs)
+	for _, g := range gains {
+		if player := s.getPlayer(game, g.playerID); player != nil {
+			s.chargeCapitalGains(game, player, g.gain, "intercambio")
+		}
+	}
+	for id, cost := range newBasis {
+		setCostBasis(game, id, cost)
+	}
+}
+
+// tradeCapitalGains works out, on the pre-trade cost basis, the gains of the participants who
+// give properties away and the new cost basis of every property that changes hands
+func (s *GameService) tradeCapitalGains(game *domain.GameState, legs []domain.TradeLeg) ([]tradeGain, map[string]int) {
+	given := make(map[string][]*domain.Tile)
+	received := make(map[string][]*domain.Tile)
+	netCash := make(map[string]int) // What each participant receives minus what it pays
+	var participants []string
+	seen := make(map[string]bool)
+	for _, leg := range legs {
+		for _, id := range []string{leg.FromID, leg.ToID} {
+			if !seen[id] {
+				seen[id] = true
+				participants = append(participants, id)
+			}
+		}
+		netCash[leg.FromID] -= leg.Cash
+		netCash[leg.ToID] += leg.Cash
+		for _, propID := range leg.Properties {
+			if t := s.propertyTile(game, propID); t != nil {
+				given[leg.FromID] = append(given[leg.FromID], t)
+				received[leg.ToID] = append(received[leg.ToID], t)
+			}
+		}
+	}
+
+	var gains []tradeGain
+	newBasis := make(map[string]int)
+	for _, id := range participants {
+		if s.getPlayer(game, id) == nil {
+			continue
+		}
+		basisOut, valueIn := 0, 0
+		for _, t := range given[id] {
+			basisOut += costBasis(game, t)
+		}
+		for _, t := range received[id] {
+			valueIn += t.Price
+		}
+		if len(given[id]) > 0 {
+			gains = append(gains, tradeGain{playerID: id, gain: netCash[id] + valueIn - basisOut})
+		}
+
+		// New basis: market value, or the cash paid when it was a pure purchase
+		for _, t := range received[id] {
+			cost := t.Price
+			if len(given[id]) == 0 && netCash[id] < 0 && valueIn > 0 {
+				cost = -netCash[id] * t.Price / valueIn
+			}
+			newBasis[t.PropertyID] = cost
+		}
+	}
+	return gains, newBasis
+}
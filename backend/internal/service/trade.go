@@ -0,0 +1,586 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+	"github.com/gabriel3312cl/finances-game/backend/internal/valuation"
+)
+
+// tradeTerms is the payload of INITIATE_TRADE and COUNTER_TRADE. Multi-party trades send
+// legs instead of a target and offer/request fields.
+type tradeTerms struct {
+	TradeID           string            `json:"trade_id"` // Offer being countered (COUNTER_TRADE only)
+	TargetID          string            `json:"target_id"`
+	OfferPropeties    []string          `json:"offer_properties"`
+	OfferCash         int               `json:"offer_cash"`
+	RequestProperties []string          `json:"request_properties"`
+	RequestCash       int               `json:"request_cash"`
+	Legs              []domain.TradeLeg `json:"legs"`
+	// Inventory items given by each side of a two-party offer
+	OfferItems   domain.Inventory      `json:"offer_items"`
+	RequestItems domain.Inventory      `json:"request_items"`
+	Terms        []domain.ContractTerm `json:"terms"`
+}
+
+// tradeRef is the payload of ACCEPT_TRADE and REJECT_TRADE
+type tradeRef struct {
+	TradeID string `json:"trade_id"`
+}
+
+// tradeLegs returns the movements of a trade; a two-party offer is expressed as two legs
+func tradeLegs(trade *domain.TradeOffer) []domain.TradeLeg {
+	if len(trade.Legs) > 0 {
+		return trade.Legs
+	}
+	return []domain.TradeLeg{
+		{FromID: trade.OffererID, ToID: trade.TargetID, Properties: trade.OfferPropeties, Cash: trade.OfferCash, Items: trade.OfferItems},
+		{FromID: trade.TargetID, ToID: trade.OffererID, Properties: trade.RequestProperties, Cash: trade.RequestCash, Items: trade.RequestItems},
+	}
+}
+
+// tradeParticipants lists everyone involved in a trade, offerer first
+func tradeParticipants(trade *domain.TradeOffer) []string {
+	participants := []string{trade.OffererID}
+	seen := map[string]bool{trade.OffererID: true}
+	for _, leg := range tradeLegs(trade) {
+		for _, id := range []string{leg.FromID, leg.ToID} {
+			if !seen[id] {
+				seen[id] = true
+				participants = append(participants, id)
+			}
+		}
+	}
+	return participants
+}
+
+// isTradeParticipant reports whether a player gives or receives anything in a trade
+func isTradeParticipant(trade *domain.TradeOffer, userID string) bool {
+	for _, id := range tradeParticipants(trade) {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// awaitingApproval reports whether a trade still needs the player's acceptance
+func awaitingApproval(trade *domain.TradeOffer, userID string) bool {
+	if len(trade.Legs) == 0 {
+		return trade.TargetID == userID
+	}
+	return userID != trade.OffererID && !trade.Approvals[userID] && isTradeParticipant(trade, userID)
+}
+
+// sortedTrades returns the open offers matching keep, oldest first
+func sortedTrades(game *domain.GameState, keep func(t *domain.TradeOffer) bool) []*domain.TradeOffer {
+	var trades []*domain.TradeOffer
+	for _, t := range game.Trades {
+		if keep(t) {
+			trades = append(trades, t)
+		}
+	}
+	sort.Slice(trades, func(i, j int) bool {
+		if trades[i].CreatedAt != trades[j].CreatedAt {
+			return trades[i].CreatedAt < trades[j].CreatedAt
+		}
+		// IDs are TR-<sequence>: shorter means older
+		if len(trades[i].ID) != len(trades[j].ID) {
+			return len(trades[i].ID) < len(trades[j].ID)
+		}
+		return trades[i].ID < trades[j].ID
+	})
+	return trades
+}
+
+// incomingTrades returns the open offers waiting for a player's answer, oldest first
+func incomingTrades(game *domain.GameState, userID string) []*domain.TradeOffer {
+	return sortedTrades(game, func(t *domain.TradeOffer) bool { return awaitingApproval(t, userID) })
+}
+
+// findTrade resolves the offer an action refers to. Without an ID it falls back to the most
+// recent offer waiting for the player (or involving them, when anyParticipant), for clients
+// that predate concurrent trades.
+func findTrade(game *domain.GameState, tradeID string, userID string, anyParticipant bool) *domain.TradeOffer {
+	matches := func(t *domain.TradeOffer) bool {
+		if anyParticipant {
+			return isTradeParticipant(t, userID)
+		}
+		return awaitingApproval(t, userID)
+	}
+	if tradeID != "" {
+		trade, ok := game.Trades[tradeID]
+		if !ok || !matches(trade) {
+			return nil
+		}
+		return trade
+	}
+	trades := sortedTrades(game, matches)
+	if len(trades) == 0 {
+		return nil
+	}
+	return trades[len(trades)-1]
+}
+
+// newTrade builds an offer from its terms. parent is the offer being countered, nil for an
+// opening offer. The offer gets its ID when stored.
+func (s *GameService) newTrade(game *domain.GameState, offerer *domain.PlayerState, terms tradeTerms, parent *domain.TradeOffer) *domain.TradeOffer {
+	now := time.Now().Unix()
+	trade := &domain.TradeOffer{
+		Revision:          1,
+		OffererID:         offerer.UserID,
+		OffererName:       offerer.Name,
+		TargetID:          terms.TargetID,
+		OfferPropeties:    terms.OfferPropeties,
+		OfferCash:         terms.OfferCash,
+		RequestProperties: terms.RequestProperties,
+		RequestCash:       terms.RequestCash,
+		Legs:              terms.Legs,
+		Terms:             terms.Terms,
+		Status:            domain.TradePending,
+		CreatedAt:         now,
+		ExpiresAt:         now + domain.TradeExpirySeconds,
+	}
+	trade.OfferItems, trade.RequestItems = terms.OfferItems, terms.RequestItems
+	if len(terms.Legs) > 0 {
+		trade.TargetID = ""
+		trade.OfferPropeties, trade.RequestProperties = nil, nil
+		trade.OfferCash, trade.RequestCash = 0, 0
+		trade.OfferItems, trade.RequestItems = nil, nil
+		trade.Approvals = map[string]bool{offerer.UserID: true}
+		var names []string
+		for _, id := range tradeParticipants(trade)[1:] {
+			if p := s.getPlayer(game, id); p != nil {
+				names = append(names, p.Name)
+			}
+		}
+		trade.TargetName = strings.Join(names, ", ")
+	} else if target := s.getPlayer(game, terms.TargetID); target != nil {
+		trade.TargetName = target.Name
+	}
+	if parent != nil {
+		trade.NegotiationID = parent.NegotiationID
+		trade.ParentID = parent.ID
+		trade.Revision = parent.Revision + 1
+	}
+	return trade
+}
+
+// storeTrade assigns the offer its ID and adds it to the open offers
+func (s *GameService) storeTrade(game *domain.GameState, trade *domain.TradeOffer) {
+	if game.Trades == nil {
+		game.Trades = make(map[string]*domain.TradeOffer)
+	}
+	game.NextTradeID++
+	trade.ID = fmt.Sprintf("TR-%d", game.NextTradeID)
+	if trade.NegotiationID == "" {
+		trade.NegotiationID = trade.ID
+	}
+	game.Trades[trade.ID] = trade
+}
+
+// closeTrade removes an offer from the open set and files it in the history
+func (s *GameService) closeTrade(game *domain.GameState, trade *domain.TradeOffer, status string) {
+	trade.Status = status
+	delete(game.Trades, trade.ID)
+	game.TradeHistory = append(game.TradeHistory, trade)
+	if len(game.TradeHistory) > domain.MaxTradeHistoryLen {
+		game.TradeHistory = game.TradeHistory[len(game.TradeHistory)-domain.MaxTradeHistoryLen:]
+	}
+}
+
+// expireTrades closes the offers whose time ran out
+func (s *GameService) expireTrades(game *domain.GameState) {
+	now := time.Now().Unix()
+	for _, trade := range sortedTrades(game, func(t *domain.TradeOffer) bool { return now >= t.ExpiresAt }) {
+		s.closeTrade(game, trade, domain.TradeExpired)
+		s.addLog(game, "Expiró la oferta de "+trade.OffererName+" a "+trade.TargetName, "INFO")
+	}
+}
+
+// cancelTradesOf closes every open offer involving a player, e.g. when they go bankrupt
+func (s *GameService) cancelTradesOf(game *domain.GameState, userID string) {
+	for _, trade := range sortedTrades(game, func(t *domain.TradeOffer) bool { return isTradeParticipant(t, userID) }) {
+		s.closeTrade(game, trade, domain.TradeRejected)
+	}
+}
+
+// mortgageTransferFee is what the receiver of a mortgaged property pays the bank
+func mortgageTransferFee(tile *domain.Tile) int {
+	mortgage := tile.MortgageValue
+	if mortgage == 0 {
+		mortgage = tile.Price / 2
+	}
+	return mortgage * domain.MortgageTransferFeePct / 100
+}
+
+// groupHasBuildings reports whether the property or any property of its color group is built on
+func groupHasBuildings(game *domain.GameState, tile *domain.Tile) bool {
+	if tile.GroupIdentifier == "" {
+		return tile.BuildingCount > 0
+	}
+	for _, t := range game.Board {
+		if t.GroupIdentifier == tile.GroupIdentifier && t.BuildingCount > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// validateTrade checks that a trade can run right now as a whole: every participant is still
+// in the game, owns what they give and can pay their cash, their mortgage fees and, out of
+// the cash they receive, their capital gains tax; no traded property belongs to a built-on
+// group and every contract clause is well formed
+func (s *GameService) validateTrade(game *domain.GameState, trade *domain.TradeOffer) error {
+	legs := tradeLegs(trade)
+	moved := make(map[string]bool)
+	due := make(map[string]int)
+	received := make(map[string]int)
+	items := make(map[string]domain.Inventory)
+	anything := len(trade.Terms) > 0
+
+	for _, leg := range legs {
+		if leg.FromID == leg.ToID || leg.Cash < 0 {
+			return errors.New("movimiento inválido")
+		}
+		from := s.getPlayer(game, leg.FromID)
+		to := s.getPlayer(game, leg.ToID)
+		if from == nil || to == nil || !from.IsActive || !to.IsActive {
+			return errors.New("uno de los participantes ya no está en la partida")
+		}
+		due[leg.FromID] += leg.Cash
+		received[leg.ToID] += leg.Cash
+		for item, n := range leg.Items {
+			if _, known := domain.Items[item]; !known || n <= 0 {
+				return errors.New("objeto inválido: " + item)
+			}
+			if items[leg.FromID] == nil {
+				items[leg.FromID] = make(domain.Inventory)
+			}
+			items[leg.FromID][item] += n
+			anything = true
+		}
+		anything = anything || leg.Cash > 0 || len(leg.Properties) > 0
+
+		for _, propID := range leg.Properties {
+			if moved[propID] {
+				return errors.New("una propiedad aparece dos veces")
+			}
+			moved[propID] = true
+
+			tile := s.propertyTile(game, propID)
+			if tile == nil {
+				return errors.New("propiedad desconocida: " + propID)
+			}
+			if game.PropertyOwnership[propID] != leg.FromID {
+				return errors.New(from.Name + " ya no posee " + tile.Name)
+			}
+			if groupHasBuildings(game, tile) {
+				return errors.New("hay edificios en el grupo de " + tile.Name + "; véndelos antes de negociar")
+			}
+			if tile.IsMortgaged {
+				due[leg.ToID] += mortgageTransferFee(tile)
+			}
+		}
+	}
+	if !anything {
+		return errors.New("el intercambio está vacío")
+	}
+
+	taxes := make(map[string]int)
+	gains, _ := s.tradeCapitalGains(game, legs)
+	for _, g := range gains {
+		taxes[g.playerID] = capitalGainsTax(game, g.gain)
+	}
+
+	for _, id := range tradeParticipants(trade) {
+		p := s.getPlayer(game, id)
+		if p == nil {
+			continue
+		}
+		// Players in debt may still trade for cash; only what they pay out must be covered
+		if due[id] > 0 && p.Balance < due[id] {
+			return errors.New(p.Name + " no tiene fondos suficientes ($" + strconv.Itoa(due[id]) + ")")
+		}
+		if taxes[id] > 0 && p.Balance+received[id] < due[id]+taxes[id] {
+			return errors.New(p.Name + " no puede pagar el impuesto a la ganancia de capital ($" + strconv.Itoa(taxes[id]) + ")")
+		}
+		for item, n := range items[id] {
+			if p.Inventory[item] < n {
+				return errors.New(p.Name + " no tiene suficientes '" + domain.Items[item].Name + "'")
+			}
+		}
+	}
+
+	for _, term := range trade.Terms {
+		if err := s.validateContractTerm(game, trade, term); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeTrade moves the cash and properties of a validated trade. Mortgaged properties keep
+// their mortgage and the receiver pays the transfer fee.
+func (s *GameService) executeTrade(game *domain.GameState, trade *domain.TradeOffer) {
+	legs := tradeLegs(trade)
+
+	// Capital gains are computed on the pre-trade cost basis
+	s.applyTradeCapitalGains(game, legs)
+
+	for _, leg := range legs {
+		s.transfer(game, leg.FromID, leg.ToID, leg.Cash, domain.ReasonTrade, trade.ID)
+		for item, n := range leg.Items {
+			takeItem(s.getPlayer(game, leg.FromID), item, n)
+			giveItem(s.getPlayer(game, leg.ToID), item, n)
+		}
+	}
+	for _, leg := range legs {
+		receiver := s.getPlayer(game, leg.ToID)
+		for _, propID := range leg.Properties {
+			tile := s.propertyTile(game, propID)
+			newOwner := leg.ToID
+			game.PropertyOwnership[propID] = newOwner
+			tile.OwnerID = &newOwner
+			if tile.IsMortgaged {
+				fee := mortgageTransferFee(tile)
+				s.bankCollect(game, receiver, fee, domain.FlowMortgage, "Traspaso de "+tile.Name)
+				s.addLog(game, receiver.Name+" recibe "+tile.Name+" hipotecada y paga $"+strconv.Itoa(fee)+" de traspaso", "INFO")
+			}
+		}
+	}
+	s.signContracts(game, trade)
+}
+
+// scoreTrade values a trade for each of its participants
+func scoreTrade(game *domain.GameState, trade *domain.TradeOffer) *valuation.TradeScore {
+	score := valuation.ScoreTrade(game, tradeLegs(trade), trade.Terms)
+	score.TradeID = trade.ID
+	return score
+}
+
+// EvaluateTrade scores an open offer (payload {"trade_id"}) or, with INITIATE_TRADE terms,
+// a hypothetical offer from the player, without changing the game
+func (s *GameService) EvaluateTrade(gameID string, userID string, payload json.RawMessage) (*valuation.TradeScore, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	game, ok := s.games[gameID]
+	if !ok {
+		return nil, errors.New("game not found")
+	}
+	player := s.getPlayer(game, userID)
+	if player == nil {
+		return nil, errors.New("player not in game")
+	}
+
+	var req tradeTerms
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, errors.New("invalid trade")
+	}
+	if req.TradeID != "" {
+		trade, open := game.Trades[req.TradeID]
+		if !open {
+			return nil, errors.New("trade not found")
+		}
+		return scoreTrade(game, trade), nil
+	}
+
+	if !validTradeTerms(req) || (len(req.Legs) == 0 && (req.TargetID == "" || req.TargetID == userID)) {
+		return nil, errors.New("invalid trade")
+	}
+	trade := s.newTrade(game, player, req, nil)
+	if err := s.validateTrade(game, trade); err != nil {
+		return nil, err
+	}
+	return scoreTrade(game, trade), nil
+}
+
+// validTradeTerms rejects negative cash and empty offers
+func validTradeTerms(terms tradeTerms) bool {
+	if terms.OfferCash < 0 || terms.RequestCash < 0 {
+		return false
+	}
+	for _, items := range []domain.Inventory{terms.OfferItems, terms.RequestItems} {
+		for _, n := range items {
+			if n <= 0 {
+				return false
+			}
+		}
+	}
+	return terms.OfferCash > 0 || terms.RequestCash > 0 || len(terms.OfferPropeties) > 0 || len(terms.RequestProperties) > 0 ||
+		len(terms.OfferItems) > 0 || len(terms.RequestItems) > 0 || len(terms.Terms) > 0 || len(terms.Legs) > 0
+}
+
+func (s *GameService) handleInitiateTrade(game *domain.GameState, userID string, payload json.RawMessage) {
+	var req tradeTerms
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return
+	}
+	s.expireTrades(game)
+
+	// Basic Validation
+	if !validTradeTerms(req) {
+		return
+	}
+	if len(req.Legs) == 0 && (req.TargetID == "" || req.TargetID == userID) {
+		return
+	}
+	offerer := s.getPlayer(game, userID)
+	if offerer == nil || !offerer.IsActive {
+		return
+	}
+
+	sent := sortedTrades(game, func(t *domain.TradeOffer) bool { return t.OffererID == userID })
+	if len(sent) >= domain.MaxOpenTradesPerPlayer {
+		s.addLog(game, offerer.Name+" ya tiene "+strconv.Itoa(len(sent))+" ofertas abiertas", "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+
+	trade := s.newTrade(game, offerer, req, nil)
+	if len(trade.Legs) > 0 && len(tradeParticipants(trade)) < 2 {
+		return
+	}
+	if err := s.validateTrade(game, trade); err != nil {
+		s.addLog(game, "Oferta rechazada: "+err.Error(), "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+	s.storeTrade(game, trade)
+
+	game.LastAction = offerer.Name + " propuso un intercambio a " + trade.TargetName
+	s.broadcastGameState(game)
+}
+
+// handleCounterTrade answers a two-party offer with new terms: the original is closed as
+// COUNTERED and the counter-offer, from the original target, joins the same negotiation
+func (s *GameService) handleCounterTrade(game *domain.GameState, userID string, payload json.RawMessage) {
+	var req tradeTerms
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return
+	}
+	s.expireTrades(game)
+
+	original := findTrade(game, req.TradeID, userID, false)
+	if original == nil || !validTradeTerms(req) {
+		return
+	}
+	if len(original.Legs) > 0 || len(req.Legs) > 0 {
+		s.addLog(game, "Las contraofertas solo aplican a intercambios entre dos jugadores", "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+	offerer := s.getPlayer(game, userID)
+	if offerer == nil {
+		return
+	}
+
+	req.TargetID = original.OffererID
+	counter := s.newTrade(game, offerer, req, original)
+	if err := s.validateTrade(game, counter); err != nil {
+		s.addLog(game, "Contraoferta rechazada: "+err.Error(), "ALERT")
+		s.broadcastGameState(game)
+		return
+	}
+	s.closeTrade(game, original, domain.TradeCountered)
+	s.storeTrade(game, counter)
+
+	msg := offerer.Name + " hizo una contraoferta a " + counter.TargetName + " (revisión " + strconv.Itoa(counter.Revision) + ")"
+	game.LastAction = msg
+	s.addLog(game, msg, "ACTION")
+	s.broadcastGameState(game)
+}
+
+// failTrade closes an accepted offer that can no longer be executed
+func (s *GameService) failTrade(game *domain.GameState, trade *domain.TradeOffer, reason string) {
+	s.closeTrade(game, trade, domain.TradeFailed)
+	game.LastAction = "Intercambio fallido: " + reason
+	s.addLog(game, game.LastAction, "ALERT")
+	s.broadcastGameState(game)
+}
+
+func (s *GameService) handleAcceptTrade(game *domain.GameState, userID string, payload json.RawMessage) {
+	var req tradeRef
+	if len(payload) > 0 {
+		json.Unmarshal(payload, &req)
+	}
+	s.expireTrades(game)
+
+	trade := findTrade(game, req.TradeID, userID, false)
+	if trade == nil {
+		return
+	}
+	player := s.getPlayer(game, userID)
+	if player == nil {
+		return
+	}
+
+	// Multi-party trades wait for every participant
+	if len(trade.Legs) > 0 {
+		trade.Approvals[userID] = true
+		pending := 0
+		for _, id := range tradeParticipants(trade) {
+			if !trade.Approvals[id] {
+				pending++
+			}
+		}
+		if pending > 0 {
+			s.addLog(game, player.Name+" aprobó el intercambio de "+trade.OffererName+" (faltan "+strconv.Itoa(pending)+")", "ACTION")
+			s.broadcastGameState(game)
+			return
+		}
+	}
+
+	if err := s.validateTrade(game, trade); err != nil {
+		s.failTrade(game, trade, err.Error())
+		return
+	}
+	s.executeTrade(game, trade)
+
+	msg := "Intercambio realizado entre " + trade.OffererName + " y " + trade.TargetName
+	game.LastAction = msg
+	s.addLog(game, msg, "SUCCESS")
+	s.closeTrade(game, trade, domain.TradeAccepted)
+	s.broadcastGameState(game)
+}
+
+func (s *GameService) handleRejectTrade(game *domain.GameState, userID string, payload json.RawMessage) {
+	var req tradeRef
+	if len(payload) > 0 {
+		json.Unmarshal(payload, &req)
+	}
+	s.expireTrades(game)
+
+	// Any participant can cancel/reject
+	trade := findTrade(game, req.TradeID, userID, true)
+	if trade == nil {
+		return
+	}
+
+	actorName := "Jugador"
+	if p := s.getPlayer(game, userID); p != nil {
+		actorName = p.Name
+	}
+	msg := actorName + " rechazó/canceló el intercambio"
+	game.LastAction = msg
+	s.addLog(game, msg, "ALERT")
+	s.closeTrade(game, trade, domain.TradeRejected)
+	s.broadcastGameState(game)
+}
+
+// hasSentTrade reports whether a player is waiting on an answer to one of their offers
+func hasSentTrade(game *domain.GameState, userID string) bool {
+	for _, t := range game.Trades {
+		if t.OffererID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func TestTrade_ThreePartyExecutesAtomically(t *testing.T) {
+	owner := func(id string) *string { return &id }
+	game := &domain.GameState{
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 500, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 500, IsActive: true},
+			{UserID: "c", Name: "C", Balance: 500, IsActive: true},
+		},
+		Board: []domain.Tile{
+			{PropertyID: "P1", Name: "Uno", Price: 200, GroupIdentifier: "G1", OwnerID: owner("a")},
+			{PropertyID: "P2", Name: "Dos", Price: 200, GroupIdentifier: "G2", OwnerID: owner("b"), IsMortgaged: true, MortgageValue: 100},
+			{PropertyID: "P3", Name: "Tres", Price: 200, GroupIdentifier: "G3", OwnerID: owner("c")},
+		},
+		PropertyOwnership: map[string]string{"P1": "a", "P2": "b", "P3": "c"},
+	}
+	s := &GameService{}
+	s.initTreasury(game)
+	s.initLedger(game)
+
+	trade := &domain.TradeOffer{
+		OffererID: "a",
+		Legs: []domain.TradeLeg{
+			{FromID: "a", ToID: "b", Properties: []string{"P1"}},
+			{FromID: "b", ToID: "c", Properties: []string{"P2"}},
+			{FromID: "c", ToID: "a", Properties: []string{"P3"}, Cash: 50},
+		},
+	}
+	if err := s.validateTrade(game, trade); err != nil {
+		t.Fatalf("valid trade rejected: %v", err)
+	}
+	s.executeTrade(game, trade)
+
+	for prop, want := range map[string]string{"P1": "b", "P2": "c", "P3": "a"} {
+		if game.PropertyOwnership[prop] != want || *s.propertyTile(game, prop).OwnerID != want {
+			t.Errorf("%s owned by %s, want %s", prop, game.PropertyOwnership[prop], want)
+		}
+	}
+	if !s.propertyTile(game, "P2").IsMortgaged {
+		t.Error("mortgage status must carry over")
+	}
+	// C pays 50 to A and the 10% transfer fee on P2's mortgage
+	if game.Players[0].Balance != 550 || game.Players[2].Balance != 440 {
+		t.Errorf("unexpected balances: A=%d C=%d", game.Players[0].Balance, game.Players[2].Balance)
+	}
+	if err := s.checkInvariants(game); err != nil {
+		t.Fatalf("trade broke invariants: %v", err)
+	}
+
+	// The same trade is now stale
+	if err := s.validateTrade(game, trade); err == nil {
+		t.Error("expected stale trade to be rejected")
+	}
+}
+
+func TestTrade_RejectsBuiltGroup(t *testing.T) {
+	game := &domain.GameState{
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 500, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 500, IsActive: true},
+		},
+		Board: []domain.Tile{
+			{PropertyID: "P1", Name: "Uno", GroupIdentifier: "G1"},
+			{PropertyID: "P2", Name: "Dos", GroupIdentifier: "G1", BuildingCount: 1},
+		},
+		PropertyOwnership: map[string]string{"P1": "a", "P2": "a"},
+	}
+	s := &GameService{}
+	trade := &domain.TradeOffer{OffererID: "a", TargetID: "b", OfferPropeties: []string{"P1"}, RequestCash: 100}
+	if err := s.validateTrade(game, trade); err == nil {
+		t.Error("expected trade of a built-on group to be rejected")
+	}
+}
+
+func TestTrade_SellerMustAffordCapitalGains(t *testing.T) {
+	owner := func(id string) *string { return &id }
+	game := &domain.GameState{
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 100, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 500, IsActive: true},
+		},
+		Board: []domain.Tile{
+			{PropertyID: "P1", Name: "Uno", Price: 100, GroupIdentifier: "G1", OwnerID: owner("a")},
+			{PropertyID: "P2", Name: "Dos", Price: 500, GroupIdentifier: "G2", OwnerID: owner("b")},
+		},
+		PropertyOwnership: map[string]string{"P1": "a", "P2": "b"},
+		Settings:          domain.GameSettings{Taxes: &domain.TaxRules{CapitalGainsPercent: 50}},
+	}
+	s := &GameService{}
+	s.initTreasury(game)
+	s.initLedger(game)
+
+	// A swaps a $100 property for a $500 one: $200 of tax and no cash to pay it with
+	swap := &domain.TradeOffer{OffererID: "a", TargetID: "b", OfferPropeties: []string{"P1"}, RequestProperties: []string{"P2"}}
+	if err := s.validateTrade(game, swap); err == nil {
+		t.Fatal("expected a trade the seller can't pay the tax on to be rejected")
+	}
+
+	// Selling for cash pays the tax out of the proceeds
+	sale := &domain.TradeOffer{OffererID: "a", TargetID: "b", OfferPropeties: []string{"P1"}, RequestCash: 300}
+	if err := s.validateTrade(game, sale); err != nil {
+		t.Fatalf("sale rejected: %v", err)
+	}
+	s.executeTrade(game, sale)
+	if a := game.Players[0].Balance; a != 300 {
+		t.Errorf("A has %d after selling for $300 with $100 of tax", a)
+	}
+}
+
+func TestTrade_RejectsZeroItemQuantities(t *testing.T) {
+	game := &domain.GameState{
+		Status: domain.GameStatusActive,
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 100, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 100, IsActive: true},
+		},
+	}
+	s := &GameService{}
+	s.initTreasury(game)
+	s.initLedger(game)
+
+	s.handleInitiateTrade(game, "a", []byte(`{"target_id": "b", "offer_cash": 10, "offer_items": {"SHIELD": 0}}`))
+	if len(game.Trades) != 0 {
+		t.Fatal("an offer of zero items was proposed")
+	}
+	offer := &domain.TradeOffer{OffererID: "a", TargetID: "b", OfferCash: 10, OfferItems: domain.Inventory{"SHIELD": 0}}
+	if err := s.validateTrade(game, offer); err == nil {
+		t.Error("expected zero items to be rejected")
+	}
+
+	// Moving nothing is a no-op even for players who never held an item
+	s.executeTrade(game, offer)
+	if game.Players[0].Inventory["SHIELD"] != 0 || game.Players[1].Inventory["SHIELD"] != 0 {
+		t.Error("zero items changed an inventory")
+	}
+}
+
+func TestTrade_ConcurrentOffersCountersAndExpiry(t *testing.T) {
+	game := &domain.GameState{
+		Status: domain.GameStatusActive,
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 100, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 100, IsActive: true},
+			{UserID: "c", Name: "C", Balance: 100, IsActive: true},
+		},
+	}
+	s := &GameService{}
+	s.initTreasury(game)
+	s.initLedger(game)
+
+	// Two players bid for B's attention at once
+	s.handleInitiateTrade(game, "a", []byte(`{"target_id": "b", "offer_cash": 10}`))
+	s.handleInitiateTrade(game, "c", []byte(`{"target_id": "b", "offer_cash": 20}`))
+	first, second := game.Trades["TR-1"], game.Trades["TR-2"]
+	if len(game.Trades) != 2 || first == nil || second == nil || first.OffererID != "a" || second.OffererID != "c" {
+		t.Fatalf("open offers: %v", game.Trades)
+	}
+
+	// B answers A with a revision of the same negotiation
+	s.handleCounterTrade(game, "b", []byte(`{"trade_id": "TR-1", "request_cash": 30}`))
+	counter := game.Trades["TR-3"]
+	if counter == nil || counter.TargetID != "a" || counter.ParentID != "TR-1" || counter.NegotiationID != "TR-1" || counter.Revision != 2 {
+		t.Fatalf("counter-offer: %+v", counter)
+	}
+	if _, open := game.Trades["TR-1"]; open || first.Status != domain.TradeCountered {
+		t.Errorf("the countered offer is %s", first.Status)
+	}
+
+	// Offers left waiting past their time close as expired
+	second.ExpiresAt = time.Now().Unix() - 1
+	s.expireTrades(game)
+	if _, open := game.Trades["TR-2"]; open || second.Status != domain.TradeExpired {
+		t.Errorf("the stale offer is %s", second.Status)
+	}
+	if len(game.Trades) != 1 || len(game.TradeHistory) != 2 {
+		t.Errorf("%d open and %d closed offers", len(game.Trades), len(game.TradeHistory))
+	}
+}
+
+func TestContract_RentImmunityAndRevenueShare(t *testing.T) {
+	game := &domain.GameState{
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 500, IsActive: true, Inventory: domain.Inventory{domain.ItemJailFree: 1}},
+			{UserID: "b", Name: "B", Balance: 500, IsActive: true},
+		},
+		Board: []domain.Tile{
+			{PropertyID: "P1", Name: "Uno", GroupIdentifier: "G1"},
+			{PropertyID: "P2", Name: "Dos", GroupIdentifier: "G2"},
+		},
+		PropertyOwnership: map[string]string{"P1": "a", "P2": "b"},
+	}
+	s := &GameService{}
+	s.initTreasury(game)
+	s.initLedger(game)
+
+	trade := &domain.TradeOffer{
+		ID: "TR-1", OffererID: "a", TargetID: "b", OfferItems: domain.Inventory{domain.ItemJailFree: 1},
+		Terms: []domain.ContractTerm{
+			{Type: domain.ContractRentImmunity, GrantorID: "a", BeneficiaryID: "b", GroupID: "G1", Landings: 1},
+			{Type: domain.ContractRevenueShare, GrantorID: "b", BeneficiaryID: "a", PropertyID: "P2", Percent: 50, Rounds: 3},
+		},
+	}
+	if err := s.validateTrade(game, trade); err != nil {
+		t.Fatalf("valid trade rejected: %v", err)
+	}
+	s.executeTrade(game, trade)
+	if game.Players[0].Inventory[domain.ItemJailFree] != 0 || game.Players[1].Inventory[domain.ItemJailFree] != 1 {
+		t.Error("jail-free card must change hands")
+	}
+
+	immunity := rentImmunity(game, &game.Board[0], "b", "a")
+	if immunity == nil {
+		t.Fatal("expected rent immunity for B on G1")
+	}
+	s.useRentImmunity(game, immunity, 40)
+	if rentImmunity(game, &game.Board[0], "b", "a") != nil {
+		t.Error("single-landing immunity must be used up")
+	}
+
+	s.shareRentRevenue(game, &game.Board[1], 100, game.Players[1])
+	if game.Players[0].Balance != 550 || game.Players[1].Balance != 450 {
+		t.Errorf("unexpected balances: A=%d B=%d", game.Players[0].Balance, game.Players[1].Balance)
+	}
+
+	game.Round = 3
+	s.expireContracts(game)
+	if len(game.Contracts) != 0 {
+		t.Errorf("expected contracts to expire, %d left", len(game.Contracts))
+	}
+}
+
+func TestContract_PayOnGoLeavesShortGrantorInDebt(t *testing.T) {
+	game := &domain.GameState{
+		Status:        domain.GameStatusActive,
+		CurrentTurnID: "a",
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 30, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 500, IsActive: true},
+		},
+		Contracts: []*domain.Contract{{ID: "C-1", Type: domain.ContractPayOnGo, GrantorID: "a", BeneficiaryID: "b", BeneficiaryName: "B", Amount: 100}},
+	}
+	s := &GameService{}
+	s.initTreasury(game)
+	s.initLedger(game)
+
+	s.settleGoContracts(game, game.Players[0])
+	if game.Players[0].Balance != -70 || game.Players[1].Balance != 600 {
+		t.Fatalf("unexpected balances: A=%d B=%d", game.Players[0].Balance, game.Players[1].Balance)
+	}
+	if last := game.Logs[len(game.Logs)-1]; last.Type != "ALERT" {
+		t.Errorf("the debt must be announced, last log: %+v", last)
+	}
+	if actions := s.LegalActions(game, "a"); !slices.ContainsFunc(actions, func(a domain.LegalAction) bool { return a.Action == "DECLARE_BANKRUPTCY" }) {
+		t.Errorf("a grantor in debt must be offered bankruptcy: %+v", actions)
+	}
+}
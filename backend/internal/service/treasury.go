@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+// initTreasury opens the bank's books with the cash handed out at game start
+func (s *GameService) initTreasury(game *domain.GameState) {
+	initial := 0
+	for _, p := range game.Players {
+		initial += p.Balance + p.Savings
+	}
+	game.Treasury = &domain.Treasury{
+		InitialMoney: initial,
+		Inflows:      make(map[string]int),
+		Outflows:     make(map[string]int),
+		Entries:      []domain.TreasuryEntry{},
+	}
+}
+
+// recordFlow books a movement in the treasury (no-op for games started before the treasury existed)
+func (s *GameService) recordFlow(game *domain.GameState, direction string, playerID string, amount int, category string, detail string) {
+	t := game.Treasury
+	if t == nil || amount == 0 {
+		return
+	}
+	if direction == domain.FlowIn {
+		t.Inflows[category] += amount
+	} else {
+		t.Outflows[category] += amount
+	}
+	t.Entries = append(t.Entries, domain.TreasuryEntry{
+		Timestamp: time.Now().Unix(),
+		Direction: direction,
+		Category:  category,
+		Amount:    amount,
+		PlayerID:  playerID,
+		Detail:    detail,
+	})
+	if len(t.Entries) > domain.MaxTreasuryEntries {
+		t.Entries = t.Entries[len(t.Entries)-domain.MaxTreasuryEntries:]
+	}
+}
+
+// bankCollect moves money from a player to the bank
+func (s *GameService) bankCollect(game *domain.GameState, player *domain.PlayerState, amount int, category string, detail string) {
+	s.transfer(game, player.UserID, domain.AccountBank, amount, category, detail)
+}
+
+// bankPay moves money from the bank to a player
+func (s *GameService) bankPay(game *domain.GameState, player *domain.PlayerState, amount int, category string, detail string) {
+	s.transfer(game, domain.AccountBank, player.UserID, amount, category, detail)
+}
+
+// collectFine charges a fine: to the Free Parking pot under the jackpot house rule, to the bank otherwise
+func (s *GameService) collectFine(game *domain.GameState, player *domain.PlayerState, amount int, category string, detail string) {
+	s.collectToBank(game, player, amount, category, detail, game.Settings.FreeParkingJackpot)
+}
+
+// collectToBank is the only way money reaches the Free Parking pot: the bank collects it and,
+// when toPot is set, keeps it in the pot instead of its vault. Both count as treasury inflows.
+func (s *GameService) collectToBank(game *domain.GameState, player *domain.PlayerState, amount int, category string, detail string, toPot bool) {
+	if toPot {
+		s.transfer(game, player.UserID, domain.AccountFreeParking, amount, category, detail)
+		return
+	}
+	s.bankCollect(game, player, amount, category, detail)
+}
+
+// bankSide tells whether an account belongs to the bank: its vault or the Free Parking pot it holds
+func bankSide(account string) bool {
+	return account == domain.AccountBank || account == domain.AccountFreeParking
+}
+
+// moneyInPlay is all the money held by players: balances and savings
+func moneyInPlay(game *domain.GameState) int {
+	total := 0
+	for _, p := range game.Players {
+		total += p.Balance + p.Savings
+	}
+	return total
+}
+
+// treasuryReport reconciles the treasury: the money in play must equal the initial money
+// plus everything the bank paid minus everything it received
+func treasuryReport(game *domain.GameState) *domain.TreasuryReport {
+	report := &domain.TreasuryReport{
+		Treasury:       game.Treasury,
+		MoneyInPlay:    moneyInPlay(game),
+		FreeParkingPot: game.FreeParkingPot,
+	}
+	if game.Treasury == nil {
+		return report
+	}
+	for _, v := range game.Treasury.Inflows {
+		report.TotalInflows += v
+	}
+	for _, v := range game.Treasury.Outflows {
+		report.TotalOutflows += v
+	}
+	report.ExpectedMoney = game.Treasury.InitialMoney + report.TotalOutflows - report.TotalInflows
+	report.Discrepancy = report.MoneyInPlay - report.ExpectedMoney
+	return report
+}
+
+// GetTreasuryReport returns the bank's books and their reconciliation for a game, to its players
+func (s *GameService) GetTreasuryReport(gameID string, userID string) (*domain.TreasuryReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	game, ok := s.games[gameID]
+	if !ok {
+		return nil, errors.New("game not found")
+	}
+	if s.getPlayer(game, userID) == nil {
+		return nil, errors.New("player not in game")
+	}
+	if game.Treasury == nil {
+		return nil, errors.New("game has no treasury (started before it was introduced)")
+	}
+	report := treasuryReport(game)
+
+	// Copy the books so the caller can encode them after the lock is released
+	books := *game.Treasury
+	books.Inflows = make(map[string]int, len(game.Treasury.Inflows))
+	for k, v := range game.Treasury.Inflows {
+		books.Inflows[k] = v
+	}
+	books.Outflows = make(map[string]int, len(game.Treasury.Outflows))
+	for k, v := range game.Treasury.Outflows {
+		books.Outflows[k] = v
+	}
+	books.Entries = append([]domain.TreasuryEntry(nil), game.Treasury.Entries...)
+	report.Treasury = &books
+	return report, nil
+}
@@ -0,0 +1,27 @@
+package valuation
+
+import "github.com/gabriel3312cl/finances-game/backend/internal/domain"
+
+// visitPrior is the number of virtual visits given to every tile so that early games,
+// with few recorded visits, fall back to a uniform distribution
+const visitPrior = 5
+
+// LandingProbabilities estimates the chance that a single move ends on each tile,
+// blending the game's recorded tile visits with a uniform prior
+func LandingProbabilities(game *domain.GameState) []float64 {
+	n := len(game.Board)
+	probs := make([]float64, n)
+	if n == 0 {
+		return probs
+	}
+
+	total := 0
+	for i := 0; i < n; i++ {
+		total += game.TileVisits[i]
+	}
+	denom := float64(total + visitPrior*n)
+	for i := 0; i < n; i++ {
+		probs[i] = float64(game.TileVisits[i]+visitPrior) / denom
+	}
+	return probs
+}
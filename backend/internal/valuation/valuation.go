@@ -0,0 +1,354 @@
+package valuation
+
+import (
+	"math"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+const (
+	// HorizonRounds is the number of table rounds a property is expected to earn rent over
+	HorizonRounds = 10
+	// LiquidityReserve is the cash below which a player values money above its face value
+	LiquidityReserve = 300
+	// averageDiceRoll is used to price dice-based rents
+	averageDiceRoll = 7
+)
+
+// PropertyValue is what a property is worth to a specific player
+type PropertyValue struct {
+	PropertyID         string  `json:"property_id"`
+	Name               string  `json:"name"`
+	Price              int     `json:"price"`
+	LandingProbability float64 `json:"landing_probability"`
+	ExpectedRent       int     `json:"expected_rent"`      // Rent expected from opponents over the horizon
+	GroupBonus         int     `json:"group_bonus"`        // Premium for completing, advancing or blocking a group
+	BuildingPotential  int     `json:"building_potential"` // Extra rent houses could earn, scaled by what the player can afford
+	MortgageCost       int     `json:"mortgage_cost"`      // Cost of lifting the mortgage, if any
+	Value              int     `json:"value"`
+}
+
+// SideScore is how a trade looks from one participant's point of view
+type SideScore struct {
+	PlayerID   string          `json:"player_id"`
+	Name       string          `json:"name"`
+	Gives      int             `json:"gives"`
+	Receives   int             `json:"receives"`
+	Net        int             `json:"net"`
+	Properties []PropertyValue `json:"properties"` // Properties received, valued for this player
+}
+
+// TradeScore scores a whole trade for each side
+type TradeScore struct {
+	TradeID string      `json:"trade_id,omitempty"`
+	Sides   []SideScore `json:"sides"`
+	Fair    bool        `json:"fair"` // No side loses more than 10% of what it gives
+}
+
+// Side returns the score of a participant, nil if they take no part in the trade
+func (t *TradeScore) Side(playerID string) *SideScore {
+	for i := range t.Sides {
+		if t.Sides[i].PlayerID == playerID {
+			return &t.Sides[i]
+		}
+	}
+	return nil
+}
+
+// Property values a property for a player as if they owned it, under the current ownership
+func Property(game *domain.GameState, playerID string, propertyID string) *PropertyValue {
+	idx := findTile(game, propertyID)
+	if idx < 0 {
+		return nil
+	}
+	ownership := copyOwnership(game.PropertyOwnership)
+	ownership[propertyID] = playerID
+	v := propertyValue(game, LandingProbabilities(game), ownership, playerID, idx)
+	return &v
+}
+
+// ScoreTrade values every movement of a trade for the players involved: properties given are
+// valued under the current ownership, properties received under the ownership after the trade
+func ScoreTrade(game *domain.GameState, legs []domain.TradeLeg, terms []domain.ContractTerm) *TradeScore {
+	probs := LandingProbabilities(game)
+	after := copyOwnership(game.PropertyOwnership)
+	for _, leg := range legs {
+		for _, propID := range leg.Properties {
+			after[propID] = leg.ToID
+		}
+	}
+
+	// Register every participant first so the side pointers below stay valid
+	score := &TradeScore{Fair: true}
+	join := func(playerID string) {
+		if score.Side(playerID) != nil {
+			return
+		}
+		name := ""
+		if p := findPlayer(game, playerID); p != nil {
+			name = p.Name
+		}
+		score.Sides = append(score.Sides, SideScore{PlayerID: playerID, Name: name})
+	}
+	for _, leg := range legs {
+		join(leg.FromID)
+		join(leg.ToID)
+	}
+	for _, term := range terms {
+		join(term.GrantorID)
+		join(term.BeneficiaryID)
+	}
+
+	for _, leg := range legs {
+		giver, receiver := score.Side(leg.FromID), score.Side(leg.ToID)
+		giver.Gives += cashValue(game, leg.FromID, leg.Cash) + itemsValue(leg.Items)
+		receiver.Receives += cashValue(game, leg.ToID, leg.Cash) + itemsValue(leg.Items)
+
+		for _, propID := range leg.Properties {
+			idx := findTile(game, propID)
+			if idx < 0 {
+				continue
+			}
+			giver.Gives += propertyValue(game, probs, game.PropertyOwnership, leg.FromID, idx).Value
+			received := propertyValue(game, probs, after, leg.ToID, idx)
+			receiver.Receives += received.Value
+			receiver.Properties = append(receiver.Properties, received)
+		}
+	}
+
+	for _, term := range terms {
+		worth := termValue(game, probs, after, term)
+		score.Side(term.GrantorID).Gives += worth
+		score.Side(term.BeneficiaryID).Receives += worth
+	}
+
+	for i := range score.Sides {
+		s := &score.Sides[i]
+		s.Net = s.Receives - s.Gives
+		if s.Net < -s.Gives/10 {
+			score.Fair = false
+		}
+	}
+	return score
+}
+
+// propertyValue prices the tile at a board index for a player under the given ownership
+func propertyValue(game *domain.GameState, probs []float64, ownership map[string]string, playerID string, idx int) PropertyValue {
+	tile := &game.Board[idx]
+	v := PropertyValue{PropertyID: tile.PropertyID, Name: tile.Name, Price: tile.Price, LandingProbability: probs[idx]}
+	landings := v.LandingProbability * float64(opponents(game, playerID)*HorizonRounds)
+
+	owned, size, blocker := groupStanding(game, ownership, playerID, tile.GroupIdentifier)
+	complete := size > 0 && owned == size
+	v.ExpectedRent = int(landings * float64(estimatedRent(game, ownership, playerID, tile, complete)))
+
+	if size > 1 {
+		switch {
+		case complete:
+			v.GroupBonus = tile.Price
+		case owned > 1:
+			v.GroupBonus = tile.Price * (owned - 1) / size / 2
+		}
+		// Holding the last property of someone else's group keeps them from building
+		if blocker {
+			v.GroupBonus += tile.Price / 2
+		}
+	}
+
+	if complete && tile.Type == "PROPERTY" && tile.HouseCost > 0 && tile.BuildingCount < 3 {
+		gain := float64(tile.Rent3House - estimatedRent(game, ownership, playerID, tile, true))
+		affordable := 1.0
+		if p := findPlayer(game, playerID); p != nil {
+			affordable = math.Min(1, math.Max(0, float64(p.Balance)/float64(tile.HouseCost*3*size)))
+		}
+		v.BuildingPotential = int(math.Max(0, gain*landings*affordable))
+	}
+
+	if tile.IsMortgaged {
+		v.MortgageCost = tile.UnmortgageValue
+		if v.MortgageCost == 0 {
+			v.MortgageCost = tile.MortgageValue * 11 / 10
+		}
+	}
+
+	v.Value = tile.Price + v.ExpectedRent + v.GroupBonus + v.BuildingPotential - v.MortgageCost
+	if v.Value < 0 {
+		v.Value = 0
+	}
+	return v
+}
+
+// groupStanding returns how many properties of a group the player owns, the group size, and
+// whether another single player owns all the rest of it
+func groupStanding(game *domain.GameState, ownership map[string]string, playerID string, groupID string) (owned int, size int, blocker bool) {
+	if groupID == "" {
+		return 0, 0, false
+	}
+	others := make(map[string]int)
+	for _, t := range game.Board {
+		if t.GroupIdentifier != groupID || t.PropertyID == "" {
+			continue
+		}
+		size++
+		switch owner := ownership[t.PropertyID]; owner {
+		case playerID:
+			owned++
+		case "":
+		default:
+			others[owner]++
+		}
+	}
+	blocker = owned == 1 && len(others) == 1
+	for _, n := range others {
+		blocker = blocker && n == size-1
+	}
+	return owned, size, blocker
+}
+
+// estimatedRent approximates the rent a tile charges its owner's opponents per landing
+func estimatedRent(game *domain.GameState, ownership map[string]string, ownerID string, tile *domain.Tile, complete bool) int {
+	if tile.IsMortgaged {
+		return 0
+	}
+	sameType := 0
+	for _, t := range game.Board {
+		if t.Type == tile.Type && t.PropertyID != "" && ownership[t.PropertyID] == ownerID {
+			sameType++
+		}
+	}
+
+	switch tile.Type {
+	case "PARK", "ATTRACTION":
+		if tile.RentBase > 0 {
+			return tile.RentBase
+		}
+		return 25
+	case "RAILROAD":
+		return 25 << uint(max(0, min(sameType, 4)-1))
+	case "UTILITY":
+		if sameType >= 2 {
+			return averageDiceRoll * 10
+		}
+		return averageDiceRoll * 4
+	case "DICE_MULTIPLIER":
+		multipliers := []int{4, 4, 10, 20, 40}
+		return averageDiceRoll * multipliers[min(sameType, 4)]
+	}
+
+	switch tile.BuildingCount {
+	case 1:
+		return tile.Rent1House
+	case 2:
+		return tile.Rent2House
+	case 3:
+		return tile.Rent3House
+	case 4:
+		return tile.Rent4House
+	case 5:
+		return tile.RentHotel
+	}
+	if complete {
+		if tile.RentColorGroup > 0 {
+			return tile.RentColorGroup
+		}
+		return tile.RentBase * 2
+	}
+	return tile.RentBase
+}
+
+// termValue estimates what a contract clause transfers from grantor to beneficiary
+func termValue(game *domain.GameState, probs []float64, ownership map[string]string, term domain.ContractTerm) int {
+	rounds := term.Rounds
+	if rounds == 0 {
+		rounds = HorizonRounds
+	}
+
+	switch term.Type {
+	case domain.ContractPayOnGo:
+		return term.Amount
+	case domain.ContractRevenueShare:
+		idx := findTile(game, term.PropertyID)
+		if idx < 0 {
+			return 0
+		}
+		tile := &game.Board[idx]
+		owned, size, _ := groupStanding(game, ownership, term.GrantorID, tile.GroupIdentifier)
+		rent := estimatedRent(game, ownership, term.GrantorID, tile, size > 0 && owned == size)
+		landings := probs[idx] * float64(opponents(game, term.GrantorID)*rounds)
+		return int(landings * float64(rent*term.Percent) / 100)
+	case domain.ContractRentImmunity:
+		total := 0.0
+		for i := range game.Board {
+			tile := &game.Board[i]
+			if tile.GroupIdentifier != term.GroupID || tile.PropertyID == "" || ownership[tile.PropertyID] != term.GrantorID {
+				continue
+			}
+			owned, size, _ := groupStanding(game, ownership, term.GrantorID, tile.GroupIdentifier)
+			landings := probs[i] * float64(rounds)
+			if term.Landings > 0 {
+				landings = math.Min(landings, float64(term.Landings))
+			}
+			total += landings * float64(estimatedRent(game, ownership, term.GrantorID, tile, owned == size))
+		}
+		return int(total)
+	}
+	return 0
+}
+
+// cashValue weighs money by the player's liquidity: short of the reserve, every dollar counts
+// up to 50% more
+func cashValue(game *domain.GameState, playerID string, amount int) int {
+	p := findPlayer(game, playerID)
+	if p == nil || p.Balance >= LiquidityReserve {
+		return amount
+	}
+	shortfall := float64(LiquidityReserve-max(p.Balance, 0)) / LiquidityReserve
+	return int(float64(amount) * (1 + shortfall/2))
+}
+
+// itemsValue is the catalog worth of a bundle of inventory items
+func itemsValue(items domain.Inventory) int {
+	total := 0
+	for item, n := range items {
+		total += domain.Items[item].Value * n
+	}
+	return total
+}
+
+// opponents counts the active players other than the given one
+func opponents(game *domain.GameState, playerID string) int {
+	n := 0
+	for _, p := range game.Players {
+		if p.IsActive && p.UserID != playerID {
+			n++
+		}
+	}
+	return n
+}
+
+func findPlayer(game *domain.GameState, playerID string) *domain.PlayerState {
+	for _, p := range game.Players {
+		if p.UserID == playerID {
+			return p
+		}
+	}
+	return nil
+}
+
+// findTile returns the board index of a property, -1 if it isn't on the board
+func findTile(game *domain.GameState, propertyID string) int {
+	for i := range game.Board {
+		if game.Board[i].PropertyID == propertyID {
+			return i
+		}
+	}
+	return -1
+}
+
+func copyOwnership(ownership map[string]string) map[string]string {
+	out := make(map[string]string, len(ownership))
+	for k, v := range ownership {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,41 @@
+package valuation
+
+import (
+	"testing"
+
+	"github.com/gabriel3312cl/finances-game/backend/internal/domain"
+)
+
+func TestScoreTrade_GroupCompletionOutweighsCash(t *testing.T) {
+	game := &domain.GameState{
+		Players: []*domain.PlayerState{
+			{UserID: "a", Name: "A", Balance: 1000, IsActive: true},
+			{UserID: "b", Name: "B", Balance: 1000, IsActive: true},
+		},
+		Board: []domain.Tile{
+			{ID: 0, Type: "PROPERTY", PropertyID: "P1", Price: 200, RentBase: 20, Rent3House: 400, HouseCost: 100, GroupIdentifier: "G1"},
+			{ID: 1, Type: "PROPERTY", PropertyID: "P2", Price: 200, RentBase: 20, Rent3House: 400, HouseCost: 100, GroupIdentifier: "G1"},
+		},
+		PropertyOwnership: map[string]string{"P1": "a", "P2": "b"},
+	}
+
+	// A buys P2 from B for its list price
+	score := ScoreTrade(game, []domain.TradeLeg{
+		{FromID: "a", ToID: "b", Cash: 200},
+		{FromID: "b", ToID: "a", Properties: []string{"P2"}},
+	}, nil)
+
+	a, b := score.Side("a"), score.Side("b")
+	if a == nil || b == nil {
+		t.Fatal("both players must be scored")
+	}
+	if a.Net <= 0 {
+		t.Errorf("completing the group must pay off for A, net %d", a.Net)
+	}
+	if b.Net >= 0 {
+		t.Errorf("B gives up a blocking property for list price, net %d", b.Net)
+	}
+	if score.Fair {
+		t.Error("trade should be flagged as unfair to B")
+	}
+}
@@ -62,16 +62,27 @@ CREATE TABLE IF NOT EXISTS players (
     UNIQUE(user_id, game_id)
 );
 
+-- Card Decks (named sets of cards; the host picks one, the lowest ID is the default)
+CREATE TABLE IF NOT EXISTS card_decks (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) UNIQUE NOT NULL,
+    description TEXT DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Game Cards (Chance / Community Chest)
 CREATE TABLE IF NOT EXISTS game_cards (
     id SERIAL PRIMARY KEY,
     type VARCHAR(20) NOT NULL, -- 'CHANCE' or 'COMMUNITY'
     title VARCHAR(100),       -- Optional title from user JSON
     description TEXT NOT NULL,
-    effect TEXT NOT NULL      -- "move:GO", "pay:50", etc.
+    effect TEXT NOT NULL      -- Card effect language, see backend/internal/cards: "move:GO", "pay:50", "properties>=3?pay:25@each"...
 );
 -- Migration: Add column if missing (for existing DBs)
 ALTER TABLE game_cards ADD COLUMN IF NOT EXISTS title VARCHAR(100);
+ALTER TABLE game_cards ADD COLUMN IF NOT EXISTS deck_id INT REFERENCES card_decks(id) ON DELETE CASCADE;
+ALTER TABLE game_cards ADD COLUMN IF NOT EXISTS enabled BOOLEAN DEFAULT TRUE; -- Disabled cards are never drawn
+CREATE INDEX IF NOT EXISTS idx_game_cards_deck_id ON game_cards(deck_id);
 
 -- Games Table (Active Sessions)
 CREATE TABLE IF NOT EXISTS games (
@@ -98,11 +109,12 @@ CREATE TABLE IF NOT EXISTS game_players (
     position INT DEFAULT 0,
     is_active BOOLEAN DEFAULT FALSE,
     is_bankrupt BOOLEAN DEFAULT FALSE,
-    inventory JSONB DEFAULT '[]',
+    inventory JSONB DEFAULT '{}', -- Item kind -> quantity
     joined_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     UNIQUE(game_id, user_id)
 );
 CREATE INDEX IF NOT EXISTS idx_game_players_game_id ON game_players(game_id);
+ALTER TABLE game_players ALTER COLUMN inventory SET DEFAULT '{}';
 
 -- Properties Table
 CREATE TABLE IF NOT EXISTS properties (
@@ -142,11 +154,21 @@ CREATE TABLE IF NOT EXISTS game_properties (
     UNIQUE(game_id, property_id)
 );
 
--- Board Layout
+-- Board Layout (the classic board)
 CREATE TABLE IF NOT EXISTS board_layout (
     position INT PRIMARY KEY, -- 0 to 63
     property_id UUID REFERENCES properties(id) ON DELETE SET NULL,
-    type VARCHAR(50) NOT NULL -- PROPERTY, CORNER, CHANCE, ETC.
+    type VARCHAR(50) NOT NULL -- PROPERTY, CORNER, CHANCE, TAX, LUXURY_TAX, ETC.
+);
+
+-- Custom Boards (size, corners, groups and tiles as JSON; the host picks one, the classic board otherwise)
+CREATE TABLE IF NOT EXISTS board_definitions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) UNIQUE NOT NULL,
+    description TEXT DEFAULT '',
+    definition JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
 -- Game History
@@ -176,16 +198,42 @@ CREATE TABLE IF NOT EXISTS loans (
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
--- Auctions
+-- Auctions (finished auctions with their bid history)
+-- Migration: the first version referenced the obsolete game_rooms and was never written to
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'auctions' AND column_name = 'highest_bidder') THEN
+        DROP TABLE auctions;
+    END IF;
+END $$;
 CREATE TABLE IF NOT EXISTS auctions (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-    game_id UUID REFERENCES game_rooms(id) ON DELETE CASCADE,
-    property_id UUID, 
-    highest_bid INT DEFAULT 0,
-    highest_bidder UUID REFERENCES players(id),
-    status VARCHAR(20) DEFAULT 'ACTIVE',
+    game_id VARCHAR(255) REFERENCES games(id) ON DELETE CASCADE,
+    auction_id VARCHAR(50) NOT NULL,    -- In-game ID (AU-n)
+    property_id VARCHAR(255) NOT NULL,
+    property_name VARCHAR(255),
+    format VARCHAR(30) NOT NULL,         -- ENGLISH, SEALED_FIRST_PRICE, SEALED_SECOND_PRICE, DUTCH
+    opening_bid INT NOT NULL,
+    winner_id VARCHAR(255),              -- UserID or bot ID, NULL when unsold
+    winner_name VARCHAR(255),
+    price INT DEFAULT 0,
+    status VARCHAR(20) NOT NULL,         -- SOLD, UNSOLD
+    bids JSONB NOT NULL DEFAULT '[]',
+    started_at TIMESTAMP WITH TIME ZONE,
+    ended_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(game_id, auction_id)
+);
+CREATE INDEX IF NOT EXISTS idx_auctions_game_id ON auctions(game_id);
+
+-- Share Price History (Stock market house rule)
+CREATE TABLE IF NOT EXISTS share_price_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    game_id VARCHAR(255) REFERENCES games(id) ON DELETE CASCADE,
+    group_id VARCHAR(50) NOT NULL,
+    price INT NOT NULL,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
+CREATE INDEX IF NOT EXISTS idx_share_price_history_game_id ON share_price_history(game_id, group_id);
 
 
 -- 2. SEED DATA =============================================================
@@ -193,8 +241,6 @@ CREATE TABLE IF NOT EXISTS auctions (
 -- Clear existing seed data to prevent duplicates (since we re-insert)
 TRUNCATE valid_codes CASCADE;
 -- Use CASCADE on valid_codes because users.special_code might reference it
--- Only truncate game_cards if we want to reset them to default
-TRUNCATE game_cards;
 TRUNCATE board_layout;
 TRUNCATE properties CASCADE;
 
@@ -203,14 +249,18 @@ TRUNCATE properties CASCADE;
 INSERT INTO valid_codes (code, description) VALUES ('BETA123', 'Default beta access code') ON CONFLICT DO NOTHING;
 
 -- Game Cards
+INSERT INTO card_decks (name, description) VALUES ('Clásico', 'Mazo original de Fortuna y Arca Comunal') ON CONFLICT (name) DO NOTHING;
+-- Reset only the seeded deck; decks authored from the admin panel are kept
+DELETE FROM game_cards WHERE deck_id IS NULL OR deck_id = (SELECT id FROM card_decks WHERE name = 'Clásico');
+
 INSERT INTO game_cards (type, title, description, effect) VALUES
 -- CHANCE
 ('CHANCE', 'Multa', 'Multa por exceso de velocidad (Paga 15m)', 'pay:15'),
 ('CHANCE', 'Reparaciones', 'Haz reparaciones generales en todas tus propiedades: Paga 25m/casa, 100m/hotel', 'repair:25:100'),
 ('CHANCE', 'Avanza Avenida', 'Avanza a Avenida Aleatoria (Si pasas Salida cobra 200m)', 'move:random_property'),
-('CHANCE', 'Avanza Transporte', 'Avanza al Transporte más cercano (Si tiene dueño paga doble)', 'move:nearest_railroad'),
+('CHANCE', 'Avanza Transporte', 'Avanza al Transporte más cercano (Si tiene dueño paga doble)', 'move:nearest_railroad:x2'),
 ('CHANCE', 'Pase Gratis', 'Sal de la cárcel gratis', 'jail_free'),
-('CHANCE', 'Avanza Servicio', 'Avanza al Servicio más cercano (Si tiene dueño tira dados y paga 10x)', 'move:nearest_utility'),
+('CHANCE', 'Avanza Servicio', 'Avanza al Servicio más cercano (Si tiene dueño tira dados y paga 10x)', 'move:nearest_utility:dice10'),
 ('CHANCE', 'Prestamo', 'Por cumplimiento de préstamo, cobra 150m)', 'collect:150'),
 ('CHANCE', 'Salida', 'Avanza hasta la Salida (Cobra 500m)', 'move:GO_BONUS'),
 ('CHANCE', 'Presidente', 'Elegido Presidente del Consejo. Paga 50m a cada jugador', 'pay_all:50'),
@@ -218,6 +268,8 @@ INSERT INTO game_cards (type, title, description, effect) VALUES
 ('CHANCE', 'Retroceder', 'Retrocede 3 casillas', 'move:-3'),
 ('CHANCE', 'Ultima Casilla', 'Avanza hasta la última casilla de propiedad', 'move:last_property'),
 ('CHANCE', 'Carcel', 'Ve a la Cárcel', 'move:JAIL'),
+('CHANCE', 'Escudo', 'Guarda esta tarjeta: no pagas la próxima renta', 'keep:SHIELD'),
+('CHANCE', 'Turbo', 'Guarda esta tarjeta: úsala después de tirar para volver a tirar', 'keep:MOVE_AGAIN'),
 
 -- COMMUNITY CHEST
 ('COMMUNITY', 'Seguro', 'Seguro de vida vence. Cobra 100m', 'collect:100'),
@@ -235,7 +287,10 @@ INSERT INTO game_cards (type, title, description, effect) VALUES
 ('COMMUNITY', 'Acciones', 'Venta de acciones. Cobra 50m', 'collect:50'),
 ('COMMUNITY', 'Impuestos', 'Devolución de impuestos. Cobra 20m', 'collect:20'),
 ('COMMUNITY', 'Honorarios', 'Honorarios de consultoría. Cobra 25m', 'collect:25'),
-('COMMUNITY', 'Vacaciones', 'Fondo vacacional. Cobra 100m', 'collect:100');
+('COMMUNITY', 'Vacaciones', 'Fondo vacacional. Cobra 100m', 'collect:100'),
+('COMMUNITY', 'Renta Doble', 'Guarda esta tarjeta: cobras el doble la próxima renta', 'keep:RENT_DOUBLER');
+
+UPDATE game_cards SET deck_id = (SELECT id FROM card_decks WHERE name = 'Clásico') WHERE deck_id IS NULL;
 
 
 -- 1. Insert Properties (5 Railroads now)
@@ -373,5 +428,5 @@ INSERT INTO board_layout (position, type, property_id) VALUES
 (59, 'PROPERTY', (SELECT id FROM properties WHERE slug='av-manquehue')),
 (60, 'CHANCE', NULL),
 (61, 'PROPERTY', (SELECT id FROM properties WHERE slug='av-los-trapenses')),
-(62, 'TAX', NULL), -- Impuesto Lujo
+(62, 'LUXURY_TAX', NULL), -- Impuesto Lujo
 (63, 'PROPERTY', (SELECT id FROM properties WHERE slug='av-el-rodeo'));
@@ -18,6 +18,11 @@ export default function AuctionModal({ gameState, user, sendMessage }: AuctionMo
     const auction = gameState?.active_auction;
     const [timeLeft, setTimeLeft] = useState<number>(0);
     const [customBid, setCustomBid] = useState<string>('');
+    const [dutchPrice, setDutchPrice] = useState<number>(0);
+
+    const format: string = auction?.format || 'ENGLISH';
+    const isSealed = format === 'SEALED_FIRST_PRICE' || format === 'SEALED_SECOND_PRICE';
+    const isDutch = format === 'DUTCH';
 
     // Reset Custom Bid when auction ends or changes
     useEffect(() => {
@@ -55,6 +60,13 @@ export default function AuctionModal({ gameState, user, sendMessage }: AuctionMo
             // Effective Time is the minimum, but we only auto-end if one hits 0
             setTimeLeft(Math.min(mainDiff, suddenDeathDiff));
 
+            // Dutch clock: the price drops every 2s in 15 steps down to the floor
+            if (auction.format === 'DUTCH') {
+                const step = Math.floor((auction.start_price - auction.floor_price) / 15);
+                const drops = Math.floor((now - auction.start_time * 1000) / 2000);
+                setDutchPrice(drops >= 15 || step <= 0 ? auction.floor_price : auction.start_price - drops * step);
+            }
+
             if (mainDiff <= 0 || (auction.bidder_id && suddenDeathDiff <= 0)) {
                 // Trigger backend finalization
                 sendMessage('FINALIZE_AUCTION', {});
@@ -69,6 +81,13 @@ export default function AuctionModal({ gameState, user, sendMessage }: AuctionMo
     const currentBid = auction.highest_bid || 0;
     // const propertyName above replaces the boardTiles lookup
     const isWinning = auction.bidder_id === user?.user_id;
+    const hasSealedBid = (auction.bidders || []).includes(user?.user_id);
+    const formatLabel: Record<string, string> = {
+        ENGLISH: 'Inglesa',
+        SEALED_FIRST_PRICE: 'Sobre cerrado (primer precio)',
+        SEALED_SECOND_PRICE: 'Sobre cerrado (segundo precio)',
+        DUTCH: 'Holandesa',
+    };
 
     // Get current player from gameState to check balance
     const me = gameState?.players?.find((p: any) => p.user_id === user?.user_id);
@@ -109,6 +128,7 @@ export default function AuctionModal({ gameState, user, sendMessage }: AuctionMo
                     <Typography variant="h5" color="warning.main" fontWeight="bold">
                         {propertyName}
                     </Typography>
+                    <Chip label={formatLabel[format] || format} size="small" sx={{ mt: 1, color: 'grey.300', borderColor: 'grey.700' }} variant="outlined" />
                 </Box>
 
                 {/* Timer */}
@@ -130,6 +150,64 @@ export default function AuctionModal({ gameState, user, sendMessage }: AuctionMo
                     )}
                 </Box>
 
+                {isSealed && (
+                    <Paper variant="outlined" sx={{ p: 2, bgcolor: 'grey.800', borderColor: 'grey.700', mb: 3 }}>
+                        <Box sx={{ display: 'flex', justifyContent: 'space-between', mb: 1 }}>
+                            <Typography variant="body2" color="grey.400">Oferta mínima</Typography>
+                            <Typography variant="h5" color="success.light" fontWeight="bold">${currentBid}</Typography>
+                        </Box>
+                        <Typography variant="caption" color="grey.500">
+                            Sobres entregados: {(auction.bidders || []).length}. Las ofertas se revelan al cierre.
+                        </Typography>
+                    </Paper>
+                )}
+
+                {isSealed && (
+                    <TextField
+                        fullWidth
+                        variant="outlined"
+                        placeholder={hasSealedBid ? 'Sobre entregado (puedes reemplazarlo)' : 'Tu oferta secreta'}
+                        value={customBid}
+                        onChange={(e) => setCustomBid(e.target.value)}
+                        type="number"
+                        size="small"
+                        InputProps={{
+                            startAdornment: <InputAdornment position="start"><Typography color="grey.400">$</Typography></InputAdornment>,
+                            endAdornment: (
+                                <InputAdornment position="end">
+                                    <Button
+                                        size="small"
+                                        variant="contained"
+                                        color="warning"
+                                        onClick={() => handleBid(parseInt(customBid))}
+                                        disabled={!customBid || parseInt(customBid) < currentBid || parseInt(customBid) > myBalance}
+                                    >
+                                        ENTREGAR SOBRE
+                                    </Button>
+                                </InputAdornment>
+                            ),
+                            sx: { color: 'white', bgcolor: 'grey.900' }
+                        }}
+                    />
+                )}
+
+                {isDutch && (
+                    <Box sx={{ textAlign: 'center' }}>
+                        <Typography variant="body2" color="grey.400">Precio actual</Typography>
+                        <Typography variant="h3" color="success.light" fontWeight="bold" sx={{ mb: 2 }}>${dutchPrice}</Typography>
+                        <Button
+                            fullWidth
+                            variant="contained"
+                            color="warning"
+                            onClick={() => handleBid(dutchPrice)}
+                            disabled={dutchPrice > myBalance}
+                        >
+                            ¡LO COMPRO!
+                        </Button>
+                    </Box>
+                )}
+
+                {!isSealed && !isDutch && (<>
                 {/* Status Card */}
                 <Paper variant="outlined" sx={{ p: 2, bgcolor: 'grey.800', borderColor: 'grey.700', mb: 3 }}>
                     <Box sx={{ display: 'flex', justifyContent: 'space-between', alignItems: 'flex-end', mb: 1 }}>
@@ -204,6 +282,7 @@ export default function AuctionModal({ gameState, user, sendMessage }: AuctionMo
                         ¡VAS GANANDO!
                     </Typography>
                 )}
+                </>)}
             </DialogContent>
         </Dialog>
     );
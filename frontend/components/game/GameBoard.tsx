@@ -680,6 +680,8 @@ export default function GameBoard() {
                     <List sx={{ width: '100%', maxWidth: 360, bgcolor: 'background.paper', borderRadius: 1 }}>
                         {[
                             { id: 'classic', name: 'Bot Clásico (Rápido)', desc: 'Sin IA. Juega rápido y lógico.', requiresLLM: false },
+                            { id: 'apprentice', name: 'El Aprendiz', desc: 'Simula pocas partidas. Rival accesible.', requiresLLM: false },
+                            { id: 'strategist', name: 'El Estratega', desc: 'Simula cientos de partidas. Difícil.', requiresLLM: false },
                             { id: 'balanced', name: 'Sr. Equilibrado (IA)', desc: 'Juega seguro.', requiresLLM: true },
                             { id: 'tycoon', name: 'El Magnate (IA)', desc: 'Agresivo con monopolios.', requiresLLM: true },
                             { id: 'saver', name: 'El Ahorrador (IA)', desc: 'Evita gastar.', requiresLLM: true },
@@ -32,7 +32,7 @@ export default function SoundManager() {
     // Processed Logs Tracker
     const processedLogsRef = useRef<Set<number>>(new Set());
     const lastTurnIdRef = useRef<string | null>(null);
-    const lastTradeRef = useRef<any>(null); // Use object ref
+    const lastTradeRef = useRef<string[]>([]); // IDs of the offers already notified
     const isFirstRender = useRef(true);
 
     // Helper to play sound
@@ -121,14 +121,13 @@ export default function SoundManager() {
         }
 
         // Trade Notification
-        if (gameState.active_trade && gameState.active_trade !== lastTradeRef.current) {
-            // Check if I am the target
-            if (gameState.active_trade.target_id === user.user_id || gameState.active_trade.TargetID === user.user_id) { // Case safe check
-                playSound('deal');
-            }
-            lastTradeRef.current = gameState.active_trade;
+        const incoming = Object.values(gameState.trades || {}).filter((t: any) => t.target_id === user.user_id).map((t: any) => t.id);
+        const newTrade = incoming.find((id: string) => !lastTradeRef.current.includes(id));
+        if (newTrade) {
+            playSound('deal');
         }
-    }, [gameState?.current_turn_id, gameState?.active_trade, user?.user_id]);
+        lastTradeRef.current = incoming;
+    }, [gameState?.current_turn_id, gameState?.trades, user?.user_id]);
 
     return null; // Invisible Component
 }
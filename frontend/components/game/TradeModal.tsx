@@ -42,8 +42,11 @@ export default function TradeModal({ gameState, user, sendMessage, isOpen = fals
 
     if (!user || !gameState) return null;
 
-    const activeTrade = gameState?.active_trade;
-    const isIncomingTrade = activeTrade && activeTrade.target_id === user.user_id;
+    // Oldest open offer addressed to me
+    const activeTrade = Object.values(gameState?.trades || {})
+        .filter((t: any) => t.target_id === user.user_id)
+        .sort((a: any, b: any) => a.created_at - b.created_at)[0] as any;
+    const isIncomingTrade = !!activeTrade;
 
     // Filter properties owned by user and potential targets
     const board = gameState?.board || [];
@@ -81,8 +84,8 @@ export default function TradeModal({ gameState, user, sendMessage, isOpen = fals
         handleClose();
     };
 
-    const handleAccept = () => sendMessage('ACCEPT_TRADE', {});
-    const handleReject = () => sendMessage('REJECT_TRADE', {});
+    const handleAccept = () => sendMessage('ACCEPT_TRADE', { trade_id: activeTrade?.id });
+    const handleReject = () => sendMessage('REJECT_TRADE', { trade_id: activeTrade?.id });
 
     // --- INCOMING TRADE DIALOG ---
     if (isIncomingTrade) {
@@ -5,6 +5,7 @@ import { API_URL, fetchWithAuth } from '@/lib/auth';
 export const useGameSocket = (gameId: string) => {
     const socketRef = useRef<WebSocket | null>(null);
     const setGame = useGameStore((state) => state.setGame);
+    const setLegalActions = useGameStore((state) => state.setLegalActions);
     const setConnected = useGameStore((state) => state.setConnected);
     const setUser = useGameStore((state) => state.setUser);
     const setSocket = useGameStore((state) => state.setSocket);
@@ -43,6 +44,10 @@ export const useGameSocket = (gameId: string) => {
                         const msg = JSON.parse(event.data);
                         if (msg.type === 'GAME_STATE') {
                             setGame(msg.payload);
+                            // Every change can open or close actions
+                            ws?.send(JSON.stringify({ action: 'GET_LEGAL_ACTIONS', payload: {} }));
+                        } else if (msg.type === 'LEGAL_ACTIONS') {
+                            setLegalActions(msg.payload);
                         }
                     } catch (e) {
                         console.error('WS Parse Error', e);
@@ -73,7 +78,7 @@ export const useGameSocket = (gameId: string) => {
             }
             // Cleanup store logic if desired (e.g. setConnected(false))
         };
-    }, [gameId, setGame, setConnected, setUser, setSocket]);
+    }, [gameId, setGame, setLegalActions, setConnected, setUser, setSocket]);
 
     // Send Message Helper
     const sendMessage = useCallback((action: string, payload: any) => {
@@ -57,7 +57,7 @@ interface GameState {
     dice: [number, number];
     last_action: string;
     active_auction?: any;
-    active_trade?: any;
+    trades?: Record<string, any>;
     property_ownership: Record<string, string>;
     tile_visits: Record<number, number>;
     logs: EventLog[];
@@ -65,9 +65,21 @@ interface GameState {
     drawn_card?: { id: number; type: string; title?: string; description: string; effect: string };
 }
 
+export interface LegalAction {
+    action: string;
+    description: string;
+    property_ids?: string[];
+    min_amount?: number;
+    max_amount?: number;
+    options?: string[];
+    target_ids?: string[];
+    trade_ids?: string[];
+}
+
 interface GameStore {
     // WebSocket Data
     game: GameState | null;
+    legalActions: LegalAction[]; // What this player can do right now (LEGAL_ACTIONS)
     isConnected: boolean;
     user: any | null; // Added User
     socket: WebSocket | null; // Added Socket ref (optional, but good for direct usage if needed)
@@ -78,6 +90,7 @@ interface GameStore {
 
     // Actions
     setGame: (game: GameState) => void;
+    setLegalActions: (actions: LegalAction[]) => void;
     setBoardConfig: (config: Tile[]) => void;
     setConnected: (status: boolean) => void;
     setUser: (user: any) => void;
@@ -90,6 +103,7 @@ export const useGameStore = create<GameStore>()(
         (set) => ({
             // Initial State
             game: null,
+            legalActions: [],
             isConnected: false,
             user: null,
             socket: null,
@@ -98,6 +112,7 @@ export const useGameStore = create<GameStore>()(
 
             // Actions
             setGame: (game) => set({ game }),
+            setLegalActions: (legalActions) => set({ legalActions: legalActions ?? [] }),
             setConnected: (isConnected) => set({ isConnected }),
             setBoardConfig: (boardConfig) => set({ boardConfig }),
             setUser: (user) => set({ user }),